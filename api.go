@@ -0,0 +1,113 @@
+package twiligo
+
+import (
+	"context"
+	"iter"
+)
+
+// ChannelService is the part of the Client that manages Channels.
+type ChannelService interface {
+	Channel(ctx context.Context, id string) (Channel, error)
+	Channels(ctx context.Context, opts ...ListOption) ([]Channel, error)
+	IterChannels(ctx context.Context, opts ...ListOption) iter.Seq2[Channel, error]
+	CreateChannel(ctx context.Context, channel Channel) (Channel, error)
+	UpdateChannel(ctx context.Context, sid string, update ChannelUpdate) (Channel, error)
+	DeleteChannel(ctx context.Context, sid string) error
+}
+
+// MessageService is the part of the Client that manages Messages within Channels.
+type MessageService interface {
+	Message(ctx context.Context, channelSID, messageSID string) (Message, error)
+	Messages(ctx context.Context, channelSID string, opts ...ListOption) ([]Message, error)
+	IterMessages(ctx context.Context, channelSID string, opts ...ListOption) iter.Seq2[Message, error]
+	SendMessage(ctx context.Context, channelSID string, message Message) (Message, error)
+	UpdateMessage(ctx context.Context, channelSID, messageSID string, update MessageUpdate) (Message, error)
+	DeleteMessage(ctx context.Context, channelSID, messageSID string) error
+}
+
+// MemberService is the part of the Client that manages the Members of Channels.
+type MemberService interface {
+	Member(ctx context.Context, channelSID, memberSID string) (Member, error)
+	Members(ctx context.Context, channelSID string, opts ...ListOption) ([]Member, error)
+	IterMembers(ctx context.Context, channelSID string, opts ...ListOption) iter.Seq2[Member, error]
+	AddMember(ctx context.Context, channelSID string, member Member) (Member, error)
+	UpdateMember(ctx context.Context, channelSID, memberSID string, update MemberUpdate) (Member, error)
+	RemoveMember(ctx context.Context, channelSID, memberSID string) error
+}
+
+// UserService is the part of the Client that manages Users.
+type UserService interface {
+	User(ctx context.Context, identity string) (User, error)
+	Users(ctx context.Context, opts ...ListOption) ([]User, error)
+	IterUsers(ctx context.Context, opts ...ListOption) iter.Seq2[User, error]
+	CreateUser(ctx context.Context, user User) (User, error)
+	UpdateUser(ctx context.Context, sid string, update UserUpdate) (User, error)
+	DeleteUser(ctx context.Context, sid string) error
+}
+
+// RoleService is the part of the Client that manages Roles.
+type RoleService interface {
+	Role(ctx context.Context, sid string) (Role, error)
+	Roles(ctx context.Context, opts ...ListOption) ([]Role, error)
+	IterRoles(ctx context.Context, opts ...ListOption) iter.Seq2[Role, error]
+	CreateRole(ctx context.Context, role Role) (Role, error)
+	UpdateRole(ctx context.Context, role Role) (Role, error)
+	DeleteRole(ctx context.Context, sid string) error
+}
+
+// ServiceService is the part of the Client that manages the account's Services.
+type ServiceService interface {
+	Service(ctx context.Context, sid string) (Service, error)
+	Services(ctx context.Context, opts ...ListOption) ([]Service, error)
+	IterServices(ctx context.Context, opts ...ListOption) iter.Seq2[Service, error]
+	CreateService(ctx context.Context, service Service) (Service, error)
+	UpdateService(ctx context.Context, sid string, update ServiceUpdate) (Service, error)
+	DeleteService(ctx context.Context, sid string) error
+}
+
+// ChannelWebhookService is the part of the Client that manages the webhooks of Channels. It's only
+// supported by v2.
+type ChannelWebhookService interface {
+	ChannelWebhook(ctx context.Context, channelSID, webhookSID string) (ChannelWebhook, error)
+	ChannelWebhooks(ctx context.Context, channelSID string, opts ...ListOption) ([]ChannelWebhook, error)
+	IterChannelWebhooks(ctx context.Context, channelSID string, opts ...ListOption) iter.Seq2[ChannelWebhook, error]
+	CreateChannelWebhook(ctx context.Context, channelSID string, webhook ChannelWebhook) (ChannelWebhook, error)
+	UpdateChannelWebhook(ctx context.Context, channelSID, webhookSID string, update ChannelWebhookUpdate) (ChannelWebhook, error)
+	DeleteChannelWebhook(ctx context.Context, channelSID, webhookSID string) error
+}
+
+// BindingService is the part of the Client that manages the push notification Bindings of Users.
+// It's only supported by v2.
+type BindingService interface {
+	UserBinding(ctx context.Context, identity, bindingSID string) (Binding, error)
+	UserBindings(ctx context.Context, identity string, opts ...ListOption) ([]Binding, error)
+	IterUserBindings(ctx context.Context, identity string, opts ...ListOption) iter.Seq2[Binding, error]
+	DeleteUserBinding(ctx context.Context, identity, bindingSID string) error
+}
+
+// CredentialService is the part of the Client that manages the account's push notification
+// Credentials. It's only supported by v2.
+type CredentialService interface {
+	Credential(ctx context.Context, sid string) (Credential, error)
+	Credentials(ctx context.Context, opts ...ListOption) ([]Credential, error)
+	IterCredentials(ctx context.Context, opts ...ListOption) iter.Seq2[Credential, error]
+	CreateCredential(ctx context.Context, credential Credential, secrets CredentialSecrets) (Credential, error)
+	UpdateCredential(ctx context.Context, sid string, update CredentialUpdate) (Credential, error)
+	DeleteCredential(ctx context.Context, sid string) error
+}
+
+// ChatAPI is every Chat resource the Client manages. Depend on it, or one of the narrower
+// interfaces it's made of, to swap the Client for a test double such as those in twiligomock.
+type ChatAPI interface {
+	ChannelService
+	MessageService
+	MemberService
+	UserService
+	RoleService
+	ServiceService
+	ChannelWebhookService
+	BindingService
+	CredentialService
+}
+
+var _ ChatAPI = (*Client)(nil)
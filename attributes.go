@@ -0,0 +1,79 @@
+package twiligo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// ErrInvalidAttributes is returned when attributes are not a JSON object, which Twilio requires
+// even though it stores them as a string.
+var ErrInvalidAttributes = rest.ErrInvalidAttributes
+
+// Attributed is implemented by the resources that carry JSON attributes: Channel, User, Message and
+// Member.
+type Attributed interface {
+	attributes() string
+}
+
+// AttributeSetter is implemented by pointers to the resources that carry JSON attributes.
+type AttributeSetter interface {
+	setAttributes(attributes string)
+}
+
+func (ch Channel) attributes() string          { return ch.Attributes }
+func (ch *Channel) setAttributes(attrs string) { ch.Attributes = attrs }
+func (u User) attributes() string              { return u.Attributes }
+func (u *User) setAttributes(attrs string)     { u.Attributes = attrs }
+func (m Message) attributes() string           { return m.Attributes }
+func (m *Message) setAttributes(attrs string)  { m.Attributes = attrs }
+func (m Member) attributes() string            { return m.Attributes }
+func (m *Member) setAttributes(attrs string)   { m.Attributes = attrs }
+
+// DecodeAttributes decodes the JSON attributes of a resource into a T. Empty attributes decode into
+// the zero value of T.
+func DecodeAttributes[T any](resource Attributed) (T, error) {
+	var attrs T
+
+	raw := resource.attributes()
+	if raw == "" {
+		return attrs, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &attrs); err != nil {
+		return attrs, fmt.Errorf("Failed to decode attributes: %w", err)
+	}
+
+	return attrs, nil
+}
+
+// SetAttributes encodes v as JSON and stores it as the attributes of a resource, e.g.
+// SetAttributes(&channel, attrs).
+func SetAttributes(resource AttributeSetter, v any) error {
+	attrs, err := EncodeAttributes(v)
+
+	if err != nil {
+		return err
+	}
+
+	resource.setAttributes(attrs)
+	return nil
+}
+
+// EncodeAttributes encodes v as JSON attributes, for use with update params. v must encode to a JSON
+// object.
+func EncodeAttributes(v any) (string, error) {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return "", fmt.Errorf("Failed to encode attributes: %w", err)
+	}
+
+	attrs := string(data)
+	if err := rest.ValidateAttributes(attrs); err != nil {
+		return "", err
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,138 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+)
+
+type channelAttributes struct {
+	Topic  string   `json:"topic"`
+	Pinned []string `json:"pinned,omitempty"`
+}
+
+func TestAttributesRoundTrip(t *testing.T) {
+	var channel twiligo.Channel
+	want := channelAttributes{Topic: "releases", Pinned: []string{"IM123"}}
+
+	if err := twiligo.SetAttributes(&channel, want); err != nil {
+		t.Fatalf("SetAttributes: %v", err)
+	}
+
+	if channel.Attributes != `{"topic":"releases","pinned":["IM123"]}` {
+		t.Errorf("Attributes = %s", channel.Attributes)
+	}
+
+	got, err := twiligo.DecodeAttributes[channelAttributes](channel)
+	if err != nil {
+		t.Fatalf("DecodeAttributes: %v", err)
+	}
+
+	if got.Topic != want.Topic || len(got.Pinned) != 1 || got.Pinned[0] != "IM123" {
+		t.Errorf("DecodeAttributes = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeAttributes(t *testing.T) {
+	empty, err := twiligo.DecodeAttributes[channelAttributes](twiligo.User{})
+	if err != nil || empty.Topic != "" {
+		t.Errorf("empty attributes decoded to %+v, %v", empty, err)
+	}
+
+	asMap, err := twiligo.DecodeAttributes[map[string]any](twiligo.Message{Attributes: `{"n": 1}`})
+	if err != nil || asMap["n"] != 1.0 {
+		t.Errorf("attributes decoded to %v, %v", asMap, err)
+	}
+
+	if _, err := twiligo.DecodeAttributes[channelAttributes](twiligo.Channel{Attributes: `{"topic": 1}`}); err == nil {
+		t.Error("DecodeAttributes accepted a number for a string field")
+	}
+}
+
+func TestEncodeAttributesRequiresObject(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		ok   bool
+	}{
+		{"struct", channelAttributes{Topic: "x"}, true},
+		{"map", map[string]int{"n": 1}, true},
+		{"empty map", map[string]int{}, true},
+		{"slice", []string{"a"}, false},
+		{"string", "topic", false},
+		{"number", 42, false},
+		{"nil", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := twiligo.EncodeAttributes(test.v)
+			if test.ok && err != nil {
+				t.Errorf("EncodeAttributes: %v", err)
+			}
+
+			if !test.ok && !errors.Is(err, twiligo.ErrInvalidAttributes) {
+				t.Errorf("EncodeAttributes returned %v, want ErrInvalidAttributes", err)
+			}
+
+			var user twiligo.User
+			if err := twiligo.SetAttributes(&user, test.v); (err == nil) != test.ok || (!test.ok && user.Attributes != "") {
+				t.Errorf("SetAttributes returned %v and set %q", err, user.Attributes)
+			}
+		})
+	}
+
+	if _, err := twiligo.EncodeAttributes(make(chan int)); err == nil {
+		t.Error("EncodeAttributes accepted a channel")
+	}
+}
+
+func TestCreateRejectsInvalidAttributes(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	client := twiligo.NewClient(srv.URL, "AC123", "IS0123456789abcdef0123456789abcdef", "token")
+
+	calls := map[string]func(attributes string) error{
+		"CreateChannel": func(attributes string) error {
+			_, err := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", attributes, "public"))
+			return err
+		},
+		"SendMessage": func(attributes string) error {
+			_, err := client.SendMessage(ctx, "CH123", twiligo.NewMessage("hi", attributes, "alice"))
+			return err
+		},
+		"CreateUser": func(attributes string) error {
+			_, err := client.CreateUser(ctx, twiligo.NewUser("alice", "Alice", attributes, ""))
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		requests = 0
+		for _, attributes := range []string{`["a"]`, `"topic"`, `{"topic":`, `null`} {
+			if err := call(attributes); !errors.Is(err, twiligo.ErrInvalidAttributes) {
+				t.Errorf("%s with %s returned %v, want ErrInvalidAttributes", name, attributes, err)
+			}
+		}
+
+		if requests != 0 {
+			t.Fatalf("%s sent %d requests with invalid attributes", name, requests)
+		}
+
+		for _, attributes := range []string{"", `{"topic": "x"}`} {
+			if err := call(attributes); err != nil {
+				t.Errorf("%s with %q: %v", name, attributes, err)
+			}
+		}
+	}
+}
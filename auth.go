@@ -0,0 +1,129 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuthProvider supplies the HTTP basic auth credentials for each request. It's consulted for every
+// attempt, so implementations can rotate credentials without rebuilding the Client.
+type AuthProvider interface {
+	Credentials(ctx context.Context) (username, password string, err error)
+}
+
+// AuthProviderFunc adapts a function to the AuthProvider interface.
+type AuthProviderFunc func(ctx context.Context) (username, password string, err error)
+
+// Credentials calls f.
+func (f AuthProviderFunc) Credentials(ctx context.Context) (string, string, error) {
+	return f(ctx)
+}
+
+type basicAuth struct {
+	username string
+	password string
+}
+
+func (a basicAuth) Credentials(context.Context) (string, string, error) {
+	return a.username, a.password, nil
+}
+
+// BasicAuth authenticates with an account SID and its auth token.
+func BasicAuth(accountSID, authToken string) AuthProvider {
+	return basicAuth{username: accountSID, password: authToken}
+}
+
+// APIKey authenticates with an API Key SID (SK...) and its secret. The account SID is still given to
+// New, since API Keys don't identify the account on their own.
+func APIKey(keySID, secret string) AuthProvider {
+	return basicAuth{username: keySID, password: secret}
+}
+
+// WithAPIKey authenticates requests with an API Key instead of the account's auth token.
+func WithAPIKey(keySID, secret string) Option {
+	return WithAuthProvider(APIKey(keySID, secret))
+}
+
+// WithAuthProvider sets the AuthProvider used to authenticate requests.
+func WithAuthProvider(provider AuthProvider) Option {
+	return func(c *Client) {
+		if provider != nil {
+			c.auth = provider
+		}
+	}
+}
+
+// FileAuth is an AuthProvider backed by a JSON file holding a username and password, e.g. an API
+// Key mounted from a secret store:
+//
+//	{"username": "SK...", "password": "..."}
+//
+// The file is re-read whenever its modification time changes, so credentials can be rotated while
+// the Client is running.
+type FileAuth struct {
+	path string
+
+	mu       sync.Mutex
+	modTime  time.Time
+	username string
+	password string
+}
+
+// NewFileAuth creates a FileAuth reading credentials from the given path.
+func NewFileAuth(path string) *FileAuth {
+	return &FileAuth{path: path}
+}
+
+type fileCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Credentials returns the credentials currently in the file.
+func (f *FileAuth) Credentials(context.Context) (string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if !info.ModTime().Equal(f.modTime) || f.username == "" {
+		if err := f.load(); err != nil {
+			return "", "", err
+		}
+
+		f.modTime = info.ModTime()
+	}
+
+	return f.username, f.password, nil
+}
+
+func (f *FileAuth) load() error {
+	data, err := os.ReadFile(f.path)
+
+	if err != nil {
+		return err
+	}
+
+	var creds fileCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return fmt.Errorf("Failed to parse credentials file %s: %w", f.path, err)
+	}
+
+	if creds.Username == "" || creds.Password == "" {
+		return errors.New("Credentials file must contain a username and password")
+	}
+
+	f.username = creds.Username
+	f.password = creds.Password
+
+	return nil
+}
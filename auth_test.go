@@ -0,0 +1,211 @@
+package twiligo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eriktate/twiligo"
+)
+
+// authServer answers every request with an empty list of Channels and records the basic auth
+// credentials of the last one.
+func authServer(t *testing.T) (*httptest.Server, func() string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var last string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+
+		mu.Lock()
+		last = username + ":" + password
+		mu.Unlock()
+
+		w.Write([]byte(`{"channels": [], "meta": {"key": "channels"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+}
+
+func TestWithAPIKey(t *testing.T) {
+	srv, credentials := authServer(t)
+	client := twiligo.New("AC123", "", twiligo.WithBaseURL(srv.URL), twiligo.WithServiceSID("IS0123456789abcdef0123456789abcdef"),
+		twiligo.WithAPIKey("SK123", "secret"))
+
+	if _, err := client.Channels(context.Background()); err != nil {
+		t.Fatalf("Channels: %v", err)
+	}
+
+	if got := credentials(); got != "SK123:secret" {
+		t.Errorf("authenticated as %q, want SK123:secret", got)
+	}
+}
+
+// writeCredentials writes a FileAuth file and moves its modification time forward, since a rewrite
+// within the file system's timestamp granularity would otherwise go unnoticed.
+func writeCredentials(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+}
+
+func TestFileAuthRotation(t *testing.T) {
+	srv, credentials := authServer(t)
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	start := time.Now().Add(-time.Hour)
+	writeCredentials(t, path, `{"username": "SK123", "password": "first"}`, start)
+
+	client := twiligo.New("AC123", "", twiligo.WithBaseURL(srv.URL), twiligo.WithServiceSID("IS0123456789abcdef0123456789abcdef"),
+		twiligo.WithAuthProvider(twiligo.NewFileAuth(path)))
+
+	for _, want := range []string{"SK123:first", "SK123:first"} {
+		if _, err := client.Channels(context.Background()); err != nil {
+			t.Fatalf("Channels: %v", err)
+		}
+
+		if got := credentials(); got != want {
+			t.Errorf("authenticated as %q, want %q", got, want)
+		}
+	}
+
+	writeCredentials(t, path, `{"username": "SK456", "password": "second"}`, start.Add(time.Minute))
+	if _, err := client.Channels(context.Background()); err != nil {
+		t.Fatalf("Channels: %v", err)
+	}
+
+	if got := credentials(); got != "SK456:second" {
+		t.Errorf("authenticated as %q after rotation, want SK456:second", got)
+	}
+}
+
+func TestFileAuthErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"missing file", "", "no such file"},
+		{"malformed", `{"username": `, "Failed to parse credentials file"},
+		{"no password", `{"username": "SK123"}`, "must contain a username and password"},
+	}
+
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("credentials-%d.json", i))
+			if test.content != "" {
+				writeCredentials(t, path, test.content, time.Now())
+			}
+
+			_, _, err := twiligo.NewFileAuth(path).Credentials(context.Background())
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Credentials returned %v, want an error containing %q", err, test.err)
+			}
+		})
+	}
+
+	// A failed request mustn't reach Twilio without credentials.
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests++ }))
+	defer srv.Close()
+
+	client := twiligo.New("AC123", "", twiligo.WithBaseURL(srv.URL), twiligo.WithServiceSID("IS0123456789abcdef0123456789abcdef"),
+		twiligo.WithAuthProvider(twiligo.NewFileAuth(filepath.Join(dir, "missing.json"))))
+	if _, err := client.Channels(context.Background()); err == nil || requests != 0 {
+		t.Errorf("Channels returned %v after %d requests, want an error before any", err, requests)
+	}
+}
+
+func TestFileAuthConcurrentRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	start := time.Now().Add(-time.Hour)
+	writeCredentials(t, path, `{"username": "SK123", "password": "secret-0"}`, start)
+
+	auth := twiligo.NewFileAuth(path)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				// A rewrite in progress may be read half written, which is reported rather than
+				// returned as credentials.
+				username, password, err := auth.Credentials(context.Background())
+				if err == nil && (username != "SK123" || !strings.HasPrefix(password, "secret-")) {
+					t.Errorf("got %s:%s", username, password)
+				}
+			}
+		}()
+	}
+
+	for i := 1; i <= 20; i++ {
+		writeCredentials(t, path, fmt.Sprintf(`{"username": "SK123", "password": "secret-%d"}`, i), start.Add(time.Duration(i)*time.Second))
+	}
+	wg.Wait()
+
+	if _, password, err := auth.Credentials(context.Background()); err != nil || password != "secret-20" {
+		t.Errorf("got %q and %v after the last rotation, want secret-20", password, err)
+	}
+}
+
+func TestValidateRequestRotatedAuthToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	start := time.Now().Add(-time.Hour)
+	writeCredentials(t, path, `{"username": "AC123", "password": "old-token"}`, start)
+
+	client := twiligo.New("AC123", "old-token", twiligo.WithAuthProvider(twiligo.NewFileAuth(path)))
+	params := url.Values{"EventType": {"onMessageSent"}}
+
+	signed := func(token string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/hook", strings.NewReader(params.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(twiligo.SignatureHeader, twiligo.ComputeSignature(token, "https://example.com/hook", params))
+		return req
+	}
+
+	if err := client.ValidateRequest(signed("old-token")); err != nil {
+		t.Fatalf("ValidateRequest before rotation: %v", err)
+	}
+
+	writeCredentials(t, path, `{"username": "AC123", "password": "new-token"}`, start.Add(time.Minute))
+
+	if err := client.ValidateRequest(signed("new-token")); err != nil {
+		t.Errorf("ValidateRequest after rotation: %v", err)
+	}
+
+	if err := client.ValidateRequest(signed("old-token")); err == nil {
+		t.Error("ValidateRequest accepted the old token after rotation")
+	}
+
+	// With an API Key the token given to New is used, unless WithAuthToken says otherwise.
+	keyed := twiligo.New("AC123", "old-token", twiligo.WithAPIKey("SK123", "secret"))
+	if err := keyed.ValidateRequest(signed("old-token")); err != nil {
+		t.Errorf("ValidateRequest with an API Key: %v", err)
+	}
+
+	if err := keyed.ValidateRequest(signed("new-token"), twiligo.WithAuthToken(twiligo.NewFileAuth(path))); err != nil {
+		t.Errorf("ValidateRequest WithAuthToken: %v", err)
+	}
+}
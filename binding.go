@@ -0,0 +1,140 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// BindingType is the push notification channel of a Binding.
+type BindingType string
+
+// The push notification channels Twilio supports.
+const (
+	BindingTypeAPN BindingType = "apn"
+	BindingTypeGCM BindingType = "gcm"
+	BindingTypeFCM BindingType = "fcm"
+)
+
+// Binding is the structured representation of a push notification registration of one of a User's
+// devices. Bindings are created by the client SDKs and are only exposed by v2.
+type Binding struct {
+	SID           string      `json:"sid,omitempty"`
+	AccountSID    string      `json:"account_sid,omitempty"`
+	ServiceSID    string      `json:"service_sid,omitempty"`
+	UserSID       string      `json:"user_sid,omitempty"`
+	CredentialSID string      `json:"credential_sid,omitempty"`
+	Identity      string      `json:"identity,omitempty"`
+	Endpoint      string      `json:"endpoint,omitempty"`
+	BindingType   BindingType `json:"binding_type,omitempty"`
+	MessageTypes  []string    `json:"message_types,omitempty"`
+	DateCreated   *time.Time  `json:"date_created,omitempty"`
+	DateUpdated   *time.Time  `json:"date_updated,omitempty"`
+	URL           string      `json:"url,omitempty"`
+}
+
+// UserBinding retrieves a single Binding of a User, who may be given by SID or identity.
+func (c *Client) UserBinding(ctx context.Context, identity, bindingSID string) (Binding, error) {
+	var binding Binding
+
+	if err := c.requireV2("user bindings"); err != nil {
+		return binding, err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixBinding, bindingSID); err != nil {
+		return binding, err
+	}
+
+	path, err := rest.Path("Users", identity, "Bindings", bindingSID)
+
+	if err != nil {
+		return binding, err
+	}
+
+	data, err := c.getResource(ctx, path, nil)
+
+	if err != nil {
+		return binding, err
+	}
+
+	if err := json.Unmarshal(data, &binding); err != nil {
+		return binding, err
+	}
+
+	return binding, nil
+}
+
+// UserBindings retrieves every Binding of a User, following pagination.
+func (c *Client) UserBindings(ctx context.Context, identity string, opts ...ListOption) ([]Binding, error) {
+	if err := c.requireV2("user bindings"); err != nil {
+		return nil, err
+	}
+
+	path, err := rest.Path("Users", identity, "Bindings")
+
+	if err != nil {
+		return nil, err
+	}
+
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listAll[Binding](ctx, c, listURL, opts)
+}
+
+// IterUserBindings lazily iterates over the Bindings of a User, fetching pages as needed.
+func (c *Client) IterUserBindings(ctx context.Context, identity string, opts ...ListOption) iter.Seq2[Binding, error] {
+	if err := c.requireV2("user bindings"); err != nil {
+		return rest.Failed[Binding](err)
+	}
+
+	path, err := rest.Path("Users", identity, "Bindings")
+
+	if err != nil {
+		return rest.Failed[Binding](err)
+	}
+
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
+		return rest.Failed[Binding](err)
+	}
+
+	return iterate[Binding](ctx, c, listURL, opts)
+}
+
+// DeleteUserBinding removes a Binding of a User, which stops push notifications to that device.
+func (c *Client) DeleteUserBinding(ctx context.Context, identity, bindingSID string) error {
+	if err := c.requireV2("user bindings"); err != nil {
+		return err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixBinding, bindingSID); err != nil {
+		return err
+	}
+
+	path, err := rest.Path("Users", identity, "Bindings", bindingSID)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteResource(ctx, path)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("Received data in body of DELETE: %s", string(data))
+	}
+
+	return nil
+}
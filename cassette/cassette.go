@@ -0,0 +1,448 @@
+// Package cassette records the HTTP traffic between a twiligo.Client and Twilio to a fixture file
+// and replays it, so integration tests written against the real API can run offline:
+//
+//	rec, err := cassette.New("testdata/channels.json", cassette.ModeReplay)
+//	...
+//	defer rec.Stop()
+//	client := twiligo.New(sid, token, twiligo.WithHTTPClient(rec.Client()))
+//
+// Credentials are never written to the fixture, and further fields can be scrubbed with
+// WithScrubbedFields and WithReplacement. In replay mode every request must match a recorded one.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrNoMatch is returned when replaying a request that wasn't recorded.
+var ErrNoMatch = errors.New("cassette: no recorded interaction matches request")
+
+// noMatchError reports the request that had no recording. It's permanent, so a Client with a
+// RetryPolicy fails right away instead of retrying a request that can never match.
+type noMatchError struct {
+	method string
+	url    string
+}
+
+func (e *noMatchError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrNoMatch, e.method, e.url)
+}
+
+func (e *noMatchError) Unwrap() error {
+	return ErrNoMatch
+}
+
+// Permanent reports that replaying the request again would fail the same way.
+func (e *noMatchError) Permanent() bool {
+	return true
+}
+
+// Scrubbed replaces the value of every scrubbed field in a fixture.
+const Scrubbed = "[SCRUBBED]"
+
+// Mode determines whether a Recorder talks to the real API or replays a fixture.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the fixture and fails on requests it doesn't contain.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real API and saves every interaction to the fixture on Stop.
+	ModeRecord
+)
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded part of an HTTP request.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the http.RoundTripper used to reach the real API in ModeRecord. By default
+// http.DefaultTransport is used.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithScrubbedFields replaces the values of the named form parameters, query parameters and JSON
+// properties with Scrubbed before they're written to the fixture, e.g. "Body" and "attributes".
+func WithScrubbedFields(fields ...string) Option {
+	return func(r *Recorder) {
+		for _, field := range fields {
+			r.scrubbed[strings.ToLower(field)] = true
+		}
+	}
+}
+
+// WithReplacement replaces every occurrence of secret in recorded URLs and bodies with placeholder,
+// e.g. an account SID. Requests are matched with the same replacement applied, so tests can replay
+// with either value.
+func WithReplacement(secret, placeholder string) Option {
+	return func(r *Recorder) {
+		if secret != "" {
+			r.replacements = append(r.replacements, secret, placeholder)
+		}
+	}
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions with the API.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	scrubbed     map[string]bool
+	replacements []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New creates a Recorder for the fixture at path. In ModeReplay the fixture is loaded immediately
+// and must exist.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		scrubbed:  make(map[string]bool),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("Failed to parse cassette %s: %w", path, err)
+		}
+
+		r.used = make([]bool, len(r.interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an http.Client that sends its requests through the Recorder, ready to be given to
+// twiligo.WithHTTPClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop saves the recorded interactions to the fixture in ModeRecord. It does nothing in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// Unused returns the recorded interactions that haven't been replayed, which usually means the code
+// under test stopped making a request it used to.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.interactions[i])
+		}
+	}
+
+	return unused
+}
+
+// RoundTrip records or replays a single request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := Request{
+		Method: req.Method,
+		URL:    r.scrubURL(req.URL.String()),
+		Body:   r.scrubForm(string(body)),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+
+		r.used[i] = true
+		return interaction.Response.toHTTP(req), nil
+	}
+
+	return nil, &noMatchError{method: recorded.Method, url: recorded.URL}
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headers := res.Header.Clone()
+	headers.Del("Set-Cookie")
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Headers:    headers,
+			Body:       r.scrubJSON(data),
+		},
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	return res, nil
+}
+
+func (resp Response) toHTTP(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        resp.Headers.Clone(),
+		Body:          io.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+// matches compares requests by method, URL and body, ignoring the order of query and form
+// parameters. A recorded value of Scrubbed matches any value.
+func matches(recorded, incoming Request) bool {
+	if recorded.Method != incoming.Method {
+		return false
+	}
+
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return recorded.URL == incoming.URL
+	}
+
+	incomingURL, err := url.Parse(incoming.URL)
+	if err != nil {
+		return false
+	}
+
+	if recordedURL.Scheme != incomingURL.Scheme || recordedURL.Host != incomingURL.Host || recordedURL.Path != incomingURL.Path {
+		return false
+	}
+
+	if !valuesMatch(recordedURL.Query(), incomingURL.Query()) {
+		return false
+	}
+
+	recordedForm, err := url.ParseQuery(recorded.Body)
+	if err != nil {
+		return recorded.Body == incoming.Body
+	}
+
+	incomingForm, err := url.ParseQuery(incoming.Body)
+	if err != nil {
+		return false
+	}
+
+	return valuesMatch(recordedForm, incomingForm)
+}
+
+func valuesMatch(recorded, incoming url.Values) bool {
+	if len(recorded) != len(incoming) {
+		return false
+	}
+
+	for key, values := range recorded {
+		if len(values) == 1 && values[0] == Scrubbed {
+			if _, ok := incoming[key]; ok {
+				continue
+			}
+			return false
+		}
+
+		if !slices.Equal(values, incoming[key]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func (r *Recorder) replace(s string) string {
+	if len(r.replacements) == 0 {
+		return s
+	}
+
+	return strings.NewReplacer(r.replacements...).Replace(s)
+}
+
+func (r *Recorder) scrubValues(values url.Values) {
+	for key := range values {
+		if r.scrubbed[strings.ToLower(key)] {
+			values.Set(key, Scrubbed)
+		}
+	}
+}
+
+func (r *Recorder) scrubURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return r.replace(rawURL)
+	}
+
+	// Credentials embedded in the URL are never recorded.
+	u.User = nil
+
+	query := u.Query()
+	r.scrubValues(query)
+	u.RawQuery = query.Encode()
+
+	return r.replace(u.String())
+}
+
+func (r *Recorder) scrubForm(body string) string {
+	if body == "" {
+		return ""
+	}
+
+	form, err := url.ParseQuery(body)
+	if err != nil {
+		return r.replace(body)
+	}
+
+	r.scrubValues(form)
+	return r.replace(form.Encode())
+}
+
+func (r *Recorder) scrubJSON(data []byte) string {
+	if len(r.scrubbed) == 0 {
+		return r.replace(string(data))
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return r.replace(string(data))
+	}
+
+	scrubbed, err := json.Marshal(r.scrubValue(value))
+	if err != nil {
+		return r.replace(string(data))
+	}
+
+	return r.replace(string(scrubbed))
+}
+
+func (r *Recorder) scrubValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if r.scrubbed[strings.ToLower(key)] {
+				v[key] = Scrubbed
+				continue
+			}
+
+			v[key] = r.scrubValue(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.scrubValue(item)
+		}
+	}
+
+	return value
+}
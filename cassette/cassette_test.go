@@ -0,0 +1,97 @@
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/cassette"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	fixture := filepath.Join(t.TempDir(), "channels.json")
+
+	srv := twiligotest.NewServer()
+	service := srv.AddService("cassette")
+
+	rec, err := cassette.New(fixture, cassette.ModeRecord, cassette.WithScrubbedFields("Attributes"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	recording := twiligo.NewClient(srv.URL, srv.AccountSID, service.SID, srv.AuthToken, twiligo.WithHTTPClient(rec.Client()))
+	created, err := recording.CreateChannel(ctx, twiligo.NewChannel("General", "general", `{"secret": true}`, "public"))
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Contains(string(data), srv.AuthToken) || strings.Contains(string(data), "secret") {
+		t.Errorf("fixture leaks credentials or scrubbed fields:\n%s", data)
+	}
+
+	// The server is gone, so everything below comes from the fixture.
+	player, err := cassette.New(fixture, cassette.ModeReplay)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	replaying := twiligo.NewClient(srv.URL, srv.AccountSID, service.SID, srv.AuthToken, twiligo.WithHTTPClient(player.Client()))
+	replayed, err := replaying.CreateChannel(ctx, twiligo.NewChannel("General", "general", `{"other": 1}`, "public"))
+	if err != nil {
+		t.Fatalf("replayed CreateChannel: %v", err)
+	}
+
+	if replayed.SID != created.SID {
+		t.Errorf("replayed SID %s, want %s", replayed.SID, created.SID)
+	}
+
+	if unused := player.Unused(); len(unused) != 0 {
+		t.Errorf("%d interactions weren't replayed", len(unused))
+	}
+}
+
+func TestUnmatchedRequestIsNotRetried(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "empty.json")
+	if err := os.WriteFile(fixture, []byte("[]"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	player, err := cassette.New(fixture, cassette.ModeReplay)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	retries := 0
+	client := twiligo.NewClient("https://chat.example.com/v2", "AC123", "IS0123456789abcdef0123456789abcdef", "token",
+		twiligo.WithHTTPClient(player.Client()),
+		twiligo.WithRetryPolicy(twiligo.RetryPolicy{
+			MaxAttempts: 5,
+			MinBackoff:  time.Second,
+			OnRetry:     func(twiligo.RetryEvent) { retries++ },
+		}))
+
+	_, err = client.Channels(context.Background())
+	if !errors.Is(err, cassette.ErrNoMatch) {
+		t.Fatalf("Channels returned %v, want ErrNoMatch", err)
+	}
+
+	if retries != 0 {
+		t.Errorf("an unmatched request was retried %d times", retries)
+	}
+}
@@ -1,44 +1,61 @@
 package twiligo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
 )
 
 // Channel is the structured representation of a Twilio channel.
 type Channel struct {
-	SID          string     `json:"sid"`
-	AccountSID   string     `json:"account_sid"`
-	ServiceSID   string     `json:"service_sid"`
-	UniqueName   string     `json:"unique_name,omitempty"`
-	FriendlyName string     `json:"friendly_name,omitempty"`
-	Attributes   string     `json:"attributes,omitempty"` // This is weirdly a string even though it's expected to be a JSON object.
-	Type         string     `json:"type"`
-	DateCreated  *time.Time `json:"date_created,omitempty"`
-	DateUpdated  *time.Time `json:"date_updated,omitempty"`
-	CreatedBy    string     `json:"created_by,omitempty"`
-	URL          string     `json:"url,omitempty"`
-	Links        Link       `json:"links,omitempty"`
+	SID           string     `json:"sid"`
+	AccountSID    string     `json:"account_sid"`
+	ServiceSID    string     `json:"service_sid"`
+	UniqueName    string     `json:"unique_name,omitempty"`
+	FriendlyName  string     `json:"friendly_name,omitempty"`
+	Attributes    string     `json:"attributes,omitempty"` // A JSON object encoded as a string, see DecodeAttributes and SetAttributes.
+	Type          string     `json:"type"`
+	DateCreated   *time.Time `json:"date_created,omitempty"`
+	DateUpdated   *time.Time `json:"date_updated,omitempty"`
+	CreatedBy     string     `json:"created_by,omitempty"`
+	MembersCount  int        `json:"members_count,omitempty"`  // Only returned by v2.
+	MessagesCount int        `json:"messages_count,omitempty"` // Only returned by v2.
+	URL           string     `json:"url,omitempty"`
+	Links         Link       `json:"links,omitempty"`
 }
 
 // ChannelsResponse is the structured representation of a response from Twilio for multiple channels.
 type ChannelsResponse struct {
 	Channels []Channel `json:"channels"`
+	Meta     Meta      `json:"meta"`
 }
 
 // Link is the structured representation of a Twilio response link.
 type Link struct {
-	Members  string `json:"members,omitempty"`
-	Messages string `json:"messages,omitempty"`
+	Members     string `json:"members,omitempty"`
+	Messages    string `json:"messages,omitempty"`
+	Invites     string `json:"invites,omitempty"`
+	Webhooks    string `json:"webhooks,omitempty"`
+	LastMessage string `json:"last_message,omitempty"`
 }
 
 // Channel retrieves a specific Channel from Twilio. The id can either be the SID for the channel
 // or the Unique Name assigned to the channel.
-func (c *Client) Channel(id string) (Channel, error) {
+func (c *Client) Channel(ctx context.Context, id string) (Channel, error) {
 	var channel Channel
-	data, err := c.getResource(fmt.Sprintf("Channels/%s", id), nil)
+
+	path, err := rest.Path("Channels", id)
+
+	if err != nil {
+		return channel, err
+	}
+
+	data, err := c.getResource(ctx, path, nil)
 
 	if err != nil {
 		return channel, err
@@ -51,29 +68,38 @@ func (c *Client) Channel(id string) (Channel, error) {
 	return channel, nil
 }
 
-// Channels returns all channels currently tied to the given
-func (c *Client) Channels() ([]Channel, error) {
-	data, err := c.getResource("Channels", nil)
+// Channels returns all channels currently tied to the Client's Service, following every page of
+// results unless a Limit is given.
+func (c *Client) Channels(ctx context.Context, opts ...ListOption) ([]Channel, error) {
+	listURL, err := c.resourceURL("Channels")
 
 	if err != nil {
 		return nil, err
 	}
 
-	var channelRes ChannelsResponse
-	err = json.Unmarshal(data, &channelRes)
+	return listAll[Channel](ctx, c, listURL, opts)
+}
+
+// IterChannels lazily iterates over the channels tied to the Client's Service, fetching pages on
+// demand.
+func (c *Client) IterChannels(ctx context.Context, opts ...ListOption) iter.Seq2[Channel, error] {
+	listURL, err := c.resourceURL("Channels")
 
 	if err != nil {
-		return nil, err
+		return rest.Failed[Channel](err)
 	}
 
-	// TODO: Iterate through pages if there are any.
-
-	return channelRes.Channels, nil
+	return iterate[Channel](ctx, c, listURL, opts)
 }
 
 // CreateChannel creates a new Channel in Twilio.
-func (c *Client) CreateChannel(channel Channel) (Channel, error) {
+func (c *Client) CreateChannel(ctx context.Context, channel Channel) (Channel, error) {
 	var newChannel Channel
+
+	if err := rest.ValidateAttributes(channel.Attributes); err != nil {
+		return newChannel, err
+	}
+
 	form := url.Values{}
 	form.Add("FriendlyName", channel.FriendlyName)
 	form.Add("UniqueName", channel.UniqueName)
@@ -81,7 +107,7 @@ func (c *Client) CreateChannel(channel Channel) (Channel, error) {
 	form.Add("Type", channel.Type)
 
 	payload := []byte(form.Encode())
-	data, err := c.postResource("Channels", payload, getFormHeader())
+	data, err := c.postResource(ctx, "Channels", payload, getFormHeader())
 
 	if err != nil {
 		return newChannel, err
@@ -93,16 +119,36 @@ func (c *Client) CreateChannel(channel Channel) (Channel, error) {
 	return newChannel, nil
 }
 
-// UpdateChannel updats an existing Channel in Twilio.
-func (c *Client) UpdateChannel(channel Channel) (Channel, error) {
+// ChannelUpdate describes changes to a Channel. Only the fields that are set are sent to Twilio.
+type ChannelUpdate struct {
+	FriendlyName *string
+	UniqueName   *string
+	Attributes   *string
+}
+
+// UpdateChannel updates an existing Channel in Twilio, leaving any field not set in the update as it
+// is. The sid can either be the SID for the channel or its Unique Name.
+func (c *Client) UpdateChannel(ctx context.Context, sid string, update ChannelUpdate) (Channel, error) {
 	var updatedChannel Channel
+
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedChannel, err
+	}
+
 	form := url.Values{}
-	form.Add("FriendlyName", channel.FriendlyName)
-	form.Add("UniqueName", channel.UniqueName)
-	form.Add("Attributes", channel.Attributes)
+	rest.SetString(form, "FriendlyName", update.FriendlyName)
+	rest.SetString(form, "UniqueName", update.UniqueName)
+	rest.SetString(form, "Attributes", update.Attributes)
 
 	payload := []byte(form.Encode())
-	data, err := c.postResource(fmt.Sprintf("Channels/%s", channel.SID), payload, getFormHeader())
+
+	path, err := rest.Path("Channels", sid)
+
+	if err != nil {
+		return updatedChannel, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
 
 	if err != nil {
 		return updatedChannel, err
@@ -116,8 +162,14 @@ func (c *Client) UpdateChannel(channel Channel) (Channel, error) {
 }
 
 // DeleteChannel deletes a Channel from Twilio.
-func (c *Client) DeleteChannel(sid string) error {
-	data, err := c.deleteResource(fmt.Sprintf("Channels/%s", sid))
+func (c *Client) DeleteChannel(ctx context.Context, sid string) error {
+	path, err := rest.Path("Channels", sid)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteResource(ctx, path)
 
 	if err != nil {
 		return err
@@ -0,0 +1,252 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// ChannelWebhookType is the kind of a ChannelWebhook.
+type ChannelWebhookType string
+
+// The kinds of ChannelWebhook Twilio supports.
+const (
+	ChannelWebhookTypeWebhook ChannelWebhookType = "webhook"
+	ChannelWebhookTypeTrigger ChannelWebhookType = "trigger"
+	ChannelWebhookTypeStudio  ChannelWebhookType = "studio"
+)
+
+// ChannelWebhook is the structured representation of a webhook scoped to a single Channel. Channel
+// webhooks are only supported by v2.
+type ChannelWebhook struct {
+	SID           string               `json:"sid,omitempty"`
+	AccountSID    string               `json:"account_sid,omitempty"`
+	ServiceSID    string               `json:"service_sid,omitempty"`
+	ChannelSID    string               `json:"channel_sid,omitempty"`
+	Type          ChannelWebhookType   `json:"type,omitempty"`
+	Configuration WebhookConfiguration `json:"configuration,omitempty"`
+	DateCreated   *time.Time           `json:"date_created,omitempty"`
+	DateUpdated   *time.Time           `json:"date_updated,omitempty"`
+	URL           string               `json:"url,omitempty"`
+}
+
+// WebhookConfiguration is the structured representation of the configuration of a ChannelWebhook.
+// Which fields apply depends on the webhook's type.
+type WebhookConfiguration struct {
+	URL        string   `json:"url,omitempty"`
+	Method     string   `json:"method,omitempty"`
+	Filters    []string `json:"filters,omitempty"`
+	Triggers   []string `json:"triggers,omitempty"` // Keywords that fire a trigger webhook.
+	FlowSID    string   `json:"flow_sid,omitempty"` // The Studio Flow of a studio webhook.
+	RetryCount int      `json:"retry_count,omitempty"`
+}
+
+// ChannelWebhookUpdate holds the fields to change on a ChannelWebhook. Nil fields are left as they
+// are.
+type ChannelWebhookUpdate struct {
+	URL        *string
+	Method     *string
+	Filters    []string // Replaces the webhook's filters when non-nil, an empty slice clears them.
+	Triggers   []string // Replaces the webhook's triggers when non-nil, an empty slice clears them.
+	FlowSID    *string
+	RetryCount *int
+}
+
+// ChannelWebhook retrieves a single webhook of a Channel.
+func (c *Client) ChannelWebhook(ctx context.Context, channelSID, webhookSID string) (ChannelWebhook, error) {
+	var webhook ChannelWebhook
+
+	if err := c.requireV2("channel webhooks"); err != nil {
+		return webhook, err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixWebhook, webhookSID); err != nil {
+		return webhook, err
+	}
+
+	path, err := rest.Path("Channels", channelSID, "Webhooks", webhookSID)
+
+	if err != nil {
+		return webhook, err
+	}
+
+	data, err := c.getResource(ctx, path, nil)
+
+	if err != nil {
+		return webhook, err
+	}
+
+	if err := json.Unmarshal(data, &webhook); err != nil {
+		return webhook, err
+	}
+
+	return webhook, nil
+}
+
+// ChannelWebhooks retrieves every webhook of a Channel, following pagination.
+func (c *Client) ChannelWebhooks(ctx context.Context, channelSID string, opts ...ListOption) ([]ChannelWebhook, error) {
+	if err := c.requireV2("channel webhooks"); err != nil {
+		return nil, err
+	}
+
+	path, err := rest.Path("Channels", channelSID, "Webhooks")
+
+	if err != nil {
+		return nil, err
+	}
+
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listAll[ChannelWebhook](ctx, c, listURL, opts)
+}
+
+// IterChannelWebhooks lazily iterates over the webhooks of a Channel, fetching pages as needed.
+func (c *Client) IterChannelWebhooks(ctx context.Context, channelSID string, opts ...ListOption) iter.Seq2[ChannelWebhook, error] {
+	if err := c.requireV2("channel webhooks"); err != nil {
+		return rest.Failed[ChannelWebhook](err)
+	}
+
+	path, err := rest.Path("Channels", channelSID, "Webhooks")
+
+	if err != nil {
+		return rest.Failed[ChannelWebhook](err)
+	}
+
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
+		return rest.Failed[ChannelWebhook](err)
+	}
+
+	return iterate[ChannelWebhook](ctx, c, listURL, opts)
+}
+
+// CreateChannelWebhook adds a webhook to a Channel.
+func (c *Client) CreateChannelWebhook(ctx context.Context, channelSID string, webhook ChannelWebhook) (ChannelWebhook, error) {
+	var createdWebhook ChannelWebhook
+
+	if err := c.requireV2("channel webhooks"); err != nil {
+		return createdWebhook, err
+	}
+
+	config := webhook.Configuration
+	form := url.Values{}
+	form.Add("Type", string(webhook.Type))
+	if config.URL != "" {
+		form.Add("Configuration.Url", config.URL)
+	}
+	if config.Method != "" {
+		form.Add("Configuration.Method", config.Method)
+	}
+	for _, filter := range config.Filters {
+		form.Add("Configuration.Filters", filter)
+	}
+	for _, trigger := range config.Triggers {
+		form.Add("Configuration.Triggers", trigger)
+	}
+	if config.FlowSID != "" {
+		form.Add("Configuration.FlowSid", config.FlowSID)
+	}
+	if config.RetryCount != 0 {
+		form.Add("Configuration.RetryCount", strconv.Itoa(config.RetryCount))
+	}
+	payload := []byte(form.Encode())
+
+	path, err := rest.Path("Channels", channelSID, "Webhooks")
+
+	if err != nil {
+		return createdWebhook, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
+
+	if err != nil {
+		return createdWebhook, err
+	}
+
+	if err := json.Unmarshal(data, &createdWebhook); err != nil {
+		return createdWebhook, err
+	}
+
+	return createdWebhook, nil
+}
+
+// UpdateChannelWebhook changes the configuration of a Channel's webhook. Only the non-nil fields of
+// update are sent.
+func (c *Client) UpdateChannelWebhook(ctx context.Context, channelSID, webhookSID string, update ChannelWebhookUpdate) (ChannelWebhook, error) {
+	var updatedWebhook ChannelWebhook
+
+	if err := c.requireV2("channel webhooks"); err != nil {
+		return updatedWebhook, err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixWebhook, webhookSID); err != nil {
+		return updatedWebhook, err
+	}
+
+	form := url.Values{}
+	rest.SetString(form, "Configuration.Url", update.URL)
+	rest.SetString(form, "Configuration.Method", update.Method)
+	rest.SetList(form, "Configuration.Filters", update.Filters)
+	rest.SetList(form, "Configuration.Triggers", update.Triggers)
+	rest.SetString(form, "Configuration.FlowSid", update.FlowSID)
+	rest.SetInt(form, "Configuration.RetryCount", update.RetryCount)
+	payload := []byte(form.Encode())
+
+	path, err := rest.Path("Channels", channelSID, "Webhooks", webhookSID)
+
+	if err != nil {
+		return updatedWebhook, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
+
+	if err != nil {
+		return updatedWebhook, err
+	}
+
+	if err := json.Unmarshal(data, &updatedWebhook); err != nil {
+		return updatedWebhook, err
+	}
+
+	return updatedWebhook, nil
+}
+
+// DeleteChannelWebhook removes a webhook from a Channel.
+func (c *Client) DeleteChannelWebhook(ctx context.Context, channelSID, webhookSID string) error {
+	if err := c.requireV2("channel webhooks"); err != nil {
+		return err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixWebhook, webhookSID); err != nil {
+		return err
+	}
+
+	path, err := rest.Path("Channels", channelSID, "Webhooks", webhookSID)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteResource(ctx, path)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("Received data in body of DELETE: %s", string(data))
+	}
+
+	return nil
+}
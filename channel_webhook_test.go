@@ -0,0 +1,45 @@
+package twiligo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestUpdateChannelWebhookClearsFilters(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+
+	client := srv.Client(srv.AddService("webhooks").SID)
+
+	channel, err := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public"))
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	webhook, err := client.CreateChannelWebhook(ctx, channel.SID, twiligo.ChannelWebhook{
+		Type: twiligo.ChannelWebhookTypeWebhook,
+		Configuration: twiligo.WebhookConfiguration{
+			URL:     "https://example.com/hook",
+			Method:  "POST",
+			Filters: []string{"onMessageSent"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateChannelWebhook: %v", err)
+	}
+	if len(webhook.Configuration.Filters) != 1 {
+		t.Fatalf("Filters = %v, want [onMessageSent]", webhook.Configuration.Filters)
+	}
+
+	webhook, err = client.UpdateChannelWebhook(ctx, channel.SID, webhook.SID, twiligo.ChannelWebhookUpdate{Filters: []string{}})
+	if err != nil {
+		t.Fatalf("UpdateChannelWebhook: %v", err)
+	}
+	if len(webhook.Configuration.Filters) != 0 {
+		t.Fatalf("an empty Filters left the filters at %v", webhook.Configuration.Filters)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// checkpoint records what has already been migrated, so an interrupted migration picks up where it
+// stopped instead of duplicating Conversations, Participants or Messages.
+type checkpoint struct {
+	path string
+
+	ChatServiceSID          string                      `json:"chat_service_sid"`
+	ConversationsServiceSID string                      `json:"conversations_service_sid"`
+	Users                   map[string]string           `json:"users"`    // Chat identity to Conversations User SID.
+	Channels                map[string]*channelProgress `json:"channels"` // Chat Channel SID to its progress.
+}
+
+// channelProgress is how far the migration of a single Channel has come.
+type channelProgress struct {
+	ConversationSID  string            `json:"conversation_sid"`
+	Participants     map[string]string `json:"participants"`       // Identity to Participant SID.
+	LastMessageIndex int               `json:"last_message_index"` // Chat index of the last migrated Message, -1 for none.
+	Messages         int               `json:"messages"`
+	Done             bool              `json:"done"`
+
+	// PendingMessageSID is the Chat Message that was being sent, set before sending and cleared
+	// after, so a resumed migration knows to check whether Twilio already accepted it.
+	PendingMessageSID string `json:"pending_message_sid,omitempty"`
+}
+
+// loadCheckpoint reads the checkpoint at path, or starts a new one when there is none yet. It fails
+// when the checkpoint belongs to a migration between other Services.
+func loadCheckpoint(path, chatServiceSID, conversationsServiceSID string) (*checkpoint, error) {
+	cp := &checkpoint{
+		path:                    path,
+		ChatServiceSID:          chatServiceSID,
+		ConversationsServiceSID: conversationsServiceSID,
+		Users:                   make(map[string]string),
+		Channels:                make(map[string]*channelProgress),
+	}
+
+	data, err := os.ReadFile(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("Failed to read checkpoint %s: %w", path, err)
+	}
+
+	if cp.ChatServiceSID != chatServiceSID || cp.ConversationsServiceSID != conversationsServiceSID {
+		return nil, fmt.Errorf("Checkpoint %s is for migrating %s to %s, remove it to start over", path, cp.ChatServiceSID, cp.ConversationsServiceSID)
+	}
+
+	return cp, nil
+}
+
+// channel returns the progress of a Channel, starting it when needed.
+func (cp *checkpoint) channel(sid string) *channelProgress {
+	progress, ok := cp.Channels[sid]
+	if !ok {
+		progress = &channelProgress{
+			Participants:     make(map[string]string),
+			LastMessageIndex: -1,
+		}
+		cp.Channels[sid] = progress
+	}
+
+	return progress
+}
+
+// save writes the checkpoint to a temporary file and renames it into place, so a crash never
+// leaves a truncated checkpoint behind.
+func (cp *checkpoint) save() error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(cp.path), filepath.Base(cp.path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), cp.path)
+}
@@ -0,0 +1,99 @@
+// Command migrate replays a Programmable Chat Service into a Conversation Service: its Users,
+// Channels, Members and full Message history, keeping authors, attributes, timestamps and order.
+//
+// Progress is recorded in a checkpoint file after every created resource, so an interrupted
+// migration can be resumed by running the same command again. Use -dry-run to report what would be
+// migrated without changing anything.
+//
+// Credentials are read from the same environment variables as the twiligo CLI: TWILIO_ACCOUNT_SID
+// with either TWILIO_AUTH_TOKEN or an API Key in TWILIO_API_KEY and TWILIO_API_SECRET.
+//
+//	TWILIO_ACCOUNT_SID=AC... TWILIO_API_KEY=SK... TWILIO_API_SECRET=... migrate -chat-service IS... -conversations-service IS... -dry-run
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/conversations"
+)
+
+func main() {
+	chatService := flag.String("chat-service", getenv("TWILIO_SERVICE_SID", "TWILIO_SERVICESID"), "SID of the Chat Service to migrate from")
+	conversationsService := flag.String("conversations-service", "", "SID of the Conversation Service to migrate to")
+	checkpointPath := flag.String("checkpoint", "migrate-checkpoint.json", "file recording progress, for resuming")
+	dryRun := flag.Bool("dry-run", false, "report what would be migrated without changing anything")
+	chatURL := flag.String("chat-url", twiligo.DefaultBaseURL, "base URL of the Chat API")
+	conversationsURL := flag.String("conversations-url", conversations.DefaultBaseURL, "base URL of the Conversations API")
+	flag.Parse()
+
+	accountSID := getenv("TWILIO_ACCOUNT_SID", "TWILIO_ACCOUNTSID")
+	token := getenv("TWILIO_AUTH_TOKEN", "TWILIO_AUTHTOKEN")
+	apiKey := os.Getenv("TWILIO_API_KEY")
+	apiSecret := os.Getenv("TWILIO_API_SECRET")
+
+	if accountSID == "" {
+		log.Fatal("TWILIO_ACCOUNT_SID must be set")
+	}
+
+	opts := []twiligo.Option{twiligo.WithBaseURL(*chatURL), twiligo.WithRetryPolicy(twiligo.DefaultRetryPolicy)}
+
+	switch {
+	case apiKey != "" && apiSecret != "":
+		opts = append(opts, twiligo.WithAPIKey(apiKey, apiSecret))
+	case apiKey != "":
+		log.Fatal("TWILIO_API_SECRET must be set along with TWILIO_API_KEY")
+	case token == "":
+		log.Fatal("TWILIO_AUTH_TOKEN, or TWILIO_API_KEY and TWILIO_API_SECRET, must be set")
+	}
+
+	if *chatService == "" || *conversationsService == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cp, err := loadCheckpoint(*checkpointPath, *chatService, *conversationsService)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Both APIs share one Client, and so its credentials and retries.
+	api := twiligo.New(accountSID, token, opts...)
+	m := &migrator{
+		chat:   api.ForService(*chatService),
+		conv:   conversations.New(api, conversations.WithBaseURL(*conversationsURL), conversations.WithServiceSID(*conversationsService)),
+		cp:     cp,
+		dryRun: *dryRun,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = m.run(ctx)
+
+	if *dryRun {
+		fmt.Println("Dry run, nothing was changed.")
+	}
+	m.report.write(os.Stdout, *dryRun)
+
+	if err != nil {
+		log.Fatalf("Migration stopped, run again to resume from %s: %s", *checkpointPath, err)
+	}
+}
+
+// getenv returns the first of the environment variables that is set. The older names without
+// underscores are still accepted.
+func getenv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/conversations"
+)
+
+// migrator replays a Chat Service into a Conversation Service.
+type migrator struct {
+	chat   *twiligo.Client
+	conv   *conversations.Client
+	cp     *checkpoint
+	dryRun bool
+	report report
+}
+
+// tally counts the resources of one kind.
+type tally struct {
+	Migrated int // Created by this run, or that would be created by a dry run.
+	Existing int // Already migrated by an earlier run.
+}
+
+// report summarizes a migration or, for a dry run, what a migration would do.
+type report struct {
+	Users        tally
+	Channels     tally
+	Participants tally
+	Messages     tally
+	Warnings     []string
+}
+
+func (r *report) warn(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	log.Printf("WARNING: %s", msg)
+	r.Warnings = append(r.Warnings, msg)
+}
+
+func (r *report) write(w io.Writer, dryRun bool) {
+	verb := "migrated"
+	if dryRun {
+		verb = "to migrate"
+	}
+
+	fmt.Fprintf(w, "%-13s %6d %s, %d already migrated\n", "Users:", r.Users.Migrated, verb, r.Users.Existing)
+	fmt.Fprintf(w, "%-13s %6d %s, %d already migrated\n", "Channels:", r.Channels.Migrated, verb, r.Channels.Existing)
+	fmt.Fprintf(w, "%-13s %6d %s, %d already migrated\n", "Participants:", r.Participants.Migrated, verb, r.Participants.Existing)
+	fmt.Fprintf(w, "%-13s %6d %s, %d already migrated\n", "Messages:", r.Messages.Migrated, verb, r.Messages.Existing)
+
+	if len(r.Warnings) > 0 {
+		fmt.Fprintf(w, "\n%d warnings:\n", len(r.Warnings))
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(w, "  %s\n", warning)
+		}
+	}
+}
+
+// run migrates Users first, so they keep their friendly names and attributes rather than being
+// created bare when they join their first Conversation, and then every Channel in turn.
+func (m *migrator) run(ctx context.Context) error {
+	if err := m.migrateUsers(ctx); err != nil {
+		return fmt.Errorf("Failed to migrate users: %w", err)
+	}
+
+	for channel, err := range m.chat.IterChannels(ctx) {
+		if err != nil {
+			return fmt.Errorf("Failed to list channels: %w", err)
+		}
+
+		if err := m.migrateChannel(ctx, channel); err != nil {
+			return fmt.Errorf("Failed to migrate channel %s: %w", channel.SID, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *migrator) migrateUsers(ctx context.Context) error {
+	for user, err := range m.chat.IterUsers(ctx) {
+		if err != nil {
+			return err
+		}
+
+		if _, ok := m.cp.Users[user.Identity]; ok {
+			m.report.Users.Existing++
+			continue
+		}
+
+		m.report.Users.Migrated++
+		if m.dryRun {
+			continue
+		}
+
+		created, err := m.conv.CreateUser(ctx, conversations.User{
+			Identity:     user.Identity,
+			FriendlyName: user.FriendlyName,
+			Attributes:   user.Attributes,
+		})
+
+		// The User was created by a run that stopped before saving its checkpoint.
+		if errors.Is(err, twiligo.ErrConflict) {
+			created, err = m.conv.User(ctx, user.Identity)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed to create user %q: %w", user.Identity, err)
+		}
+
+		m.cp.Users[user.Identity] = created.SID
+		if err := m.cp.save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *migrator) migrateChannel(ctx context.Context, channel twiligo.Channel) error {
+	progress := m.cp.channel(channel.SID)
+	if progress.Done {
+		m.report.Channels.Existing++
+		m.report.Participants.Existing += len(progress.Participants)
+		m.report.Messages.Existing += progress.Messages
+		return nil
+	}
+
+	log.Printf("Migrating channel %s (%s)", channel.SID, channel.UniqueName)
+
+	if progress.ConversationSID != "" {
+		m.report.Channels.Existing++
+	} else {
+		m.report.Channels.Migrated++
+		if !m.dryRun {
+			if err := m.createConversation(ctx, channel, progress); err != nil {
+				return err
+			}
+		}
+	}
+
+	members, err := m.chat.Members(ctx, channel.SID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if err := m.migrateMember(ctx, progress, member); err != nil {
+			return err
+		}
+	}
+
+	indices, err := m.migrateMessages(ctx, channel, progress)
+
+	if err != nil {
+		return err
+	}
+
+	if m.dryRun {
+		return nil
+	}
+
+	// Chat indices have gaps where Messages were deleted while Conversations numbers the replayed
+	// Messages from 0, so read horizons are translated before they're carried over.
+	for _, member := range members {
+		if member.LastConsumedMessageIndex == nil {
+			continue
+		}
+
+		index := sort.SearchInts(indices, *member.LastConsumedMessageIndex+1) - 1
+		if index < 0 {
+			continue
+		}
+
+		_, err := m.conv.UpdateParticipant(ctx, progress.ConversationSID, progress.Participants[member.Identity], conversations.ParticipantUpdate{
+			LastReadMessageIndex: &index,
+		})
+
+		if err != nil {
+			return fmt.Errorf("Failed to carry over read horizon of %q: %w", member.Identity, err)
+		}
+	}
+
+	progress.Done = true
+	return m.cp.save()
+}
+
+func (m *migrator) createConversation(ctx context.Context, channel twiligo.Channel, progress *channelProgress) error {
+	conversation, err := m.conv.CreateConversation(ctx, conversations.Conversation{
+		FriendlyName: channel.FriendlyName,
+		UniqueName:   channel.UniqueName,
+		Attributes:   channel.Attributes,
+		DateCreated:  channel.DateCreated,
+		DateUpdated:  channel.DateUpdated,
+	})
+
+	// The Conversation was created by a run that stopped before saving its checkpoint.
+	if errors.Is(err, twiligo.ErrConflict) && channel.UniqueName != "" {
+		conversation, err = m.conv.Conversation(ctx, channel.UniqueName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to create conversation: %w", err)
+	}
+
+	progress.ConversationSID = conversation.SID
+	return m.cp.save()
+}
+
+func (m *migrator) migrateMember(ctx context.Context, progress *channelProgress, member twiligo.Member) error {
+	if _, ok := progress.Participants[member.Identity]; ok {
+		m.report.Participants.Existing++
+		return nil
+	}
+
+	m.report.Participants.Migrated++
+	if m.dryRun {
+		return nil
+	}
+
+	participant, err := m.conv.AddParticipant(ctx, progress.ConversationSID, conversations.Participant{
+		Identity:    member.Identity,
+		Attributes:  member.Attributes,
+		DateCreated: member.DateCreated,
+		DateUpdated: member.DateUpdated,
+	})
+
+	if errors.Is(err, twiligo.ErrConflict) {
+		participant, err = m.findParticipant(ctx, progress.ConversationSID, member.Identity)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to add participant %q: %w", member.Identity, err)
+	}
+
+	progress.Participants[member.Identity] = participant.SID
+	return m.cp.save()
+}
+
+func (m *migrator) findParticipant(ctx context.Context, conversationSID, identity string) (conversations.Participant, error) {
+	for participant, err := range m.conv.IterParticipants(ctx, conversationSID) {
+		if err != nil {
+			return participant, err
+		}
+
+		if participant.Identity == identity {
+			return participant, nil
+		}
+	}
+
+	return conversations.Participant{}, fmt.Errorf("Participant %q exists but couldn't be found", identity)
+}
+
+// migrateMessages replays the history of a Channel in order, keeping each Message's author,
+// attributes and timestamps. It returns the Chat indices of the replayed Messages, in order.
+func (m *migrator) migrateMessages(ctx context.Context, channel twiligo.Channel, progress *channelProgress) ([]int, error) {
+	var indices []int
+
+	for message, err := range m.chat.IterMessages(ctx, channel.SID) {
+		if err != nil {
+			return nil, err
+		}
+
+		// Media belongs to the Chat Service and can't be attached to a Conversation Message, so
+		// only the body of media Messages is carried over.
+		if message.Media != nil {
+			if message.Index > progress.LastMessageIndex {
+				m.report.warn("Message %s in channel %s has media %s which isn't migrated", message.SID, channel.SID, message.Media.SID)
+			}
+
+			if message.Body == "" {
+				continue
+			}
+		}
+
+		indices = append(indices, message.Index)
+
+		if message.Index <= progress.LastMessageIndex {
+			m.report.Messages.Existing++
+			continue
+		}
+
+		if progress.PendingMessageSID == message.SID {
+			sent, err := m.wasSent(ctx, progress.ConversationSID, message)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if sent {
+				m.report.Messages.Existing++
+				if err := m.messageSent(progress, message); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		m.report.Messages.Migrated++
+		if m.dryRun {
+			continue
+		}
+
+		// Sending isn't idempotent, so the Message is marked as pending first. Should the migration
+		// stop before the checkpoint records it as sent, the next run checks the Conversation
+		// instead of sending it twice.
+		progress.PendingMessageSID = message.SID
+		if err := m.cp.save(); err != nil {
+			return nil, err
+		}
+
+		_, err := m.conv.SendMessage(ctx, progress.ConversationSID, conversations.Message{
+			Author:      message.From,
+			Body:        message.Body,
+			Attributes:  message.Attributes,
+			DateCreated: message.DateCreated,
+			DateUpdated: message.DateUpdated,
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to send message %s: %w", message.SID, err)
+		}
+
+		if err := m.messageSent(progress, message); err != nil {
+			return nil, err
+		}
+	}
+
+	return indices, nil
+}
+
+// messageSent records a Message as migrated. A dry run leaves the checkpoint untouched.
+func (m *migrator) messageSent(progress *channelProgress, message twiligo.Message) error {
+	progress.PendingMessageSID = ""
+	progress.LastMessageIndex = message.Index
+	progress.Messages++
+
+	if m.dryRun {
+		return nil
+	}
+
+	return m.cp.save()
+}
+
+// wasSent reports whether the last Message of the Conversation is the replay of the given Chat
+// Message, i.e. whether Twilio accepted it before the previous run stopped. Only the newest Message
+// is requested, however long the Conversation's history.
+func (m *migrator) wasSent(ctx context.Context, conversationSID string, message twiligo.Message) (bool, error) {
+	newest, err := m.conv.Messages(ctx, conversationSID, twiligo.Descending(), twiligo.Limit(1))
+
+	if err != nil {
+		return false, fmt.Errorf("Failed to check whether message %s was sent: %w", message.SID, err)
+	}
+
+	if len(newest) == 0 {
+		return false, nil
+	}
+
+	last := newest[0]
+	return last.Author == message.From && last.Body == message.Body && sameTime(last.DateCreated, message.DateCreated), nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
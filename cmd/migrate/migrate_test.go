@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+type fixture struct {
+	srv            *twiligotest.Server
+	chat           *twiligo.Client
+	chatSID        string
+	convSID        string
+	checkpointPath string
+	lastMessage    twiligo.Message
+}
+
+// newFixture creates a Chat Service with one Channel holding three Messages, and an empty
+// Conversation Service to migrate it to.
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	ctx := context.Background()
+
+	srv := twiligotest.NewServer()
+	t.Cleanup(srv.Close)
+
+	f := &fixture{
+		srv:            srv,
+		chatSID:        srv.AddService("chat").SID,
+		convSID:        srv.AddService("conversations").SID,
+		checkpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+	}
+	f.chat = srv.Client(f.chatSID)
+
+	if _, err := f.chat.CreateUser(ctx, twiligo.User{Identity: "alice"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	channel, err := f.chat.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public"))
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	for _, body := range []string{"one", "two", "three"} {
+		f.lastMessage, err = f.chat.SendMessage(ctx, channel.SID, twiligo.Message{From: "alice", Body: body})
+		if err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+	}
+
+	return f
+}
+
+// migrate runs the migration with the checkpoint as left by earlier runs.
+func (f *fixture) migrate(t *testing.T) report {
+	t.Helper()
+
+	cp, err := loadCheckpoint(f.checkpointPath, f.chatSID, f.convSID)
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+
+	m := &migrator{chat: f.chat, conv: f.srv.ConversationsClient(f.convSID), cp: cp}
+	if err := m.run(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	return m.report
+}
+
+// crashBeforeSaving rewinds the checkpoint to the state a crash between sending the last Message and
+// recording it leaves behind.
+func (f *fixture) crashBeforeSaving(t *testing.T) *channelProgress {
+	t.Helper()
+
+	cp, err := loadCheckpoint(f.checkpointPath, f.chatSID, f.convSID)
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+
+	progress := cp.Channels[f.lastMessage.ChannelSID]
+	progress.LastMessageIndex = f.lastMessage.Index - 1
+	progress.Messages--
+	progress.PendingMessageSID = f.lastMessage.SID
+	progress.Done = false
+
+	if err := cp.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	return progress
+}
+
+func (f *fixture) conversationBodies(t *testing.T, conversationSID string) []string {
+	t.Helper()
+
+	messages, err := f.srv.ConversationsClient(f.convSID).Messages(context.Background(), conversationSID)
+	if err != nil {
+		t.Fatalf("Messages: %v", err)
+	}
+
+	bodies := make([]string, len(messages))
+	for i, message := range messages {
+		bodies[i] = message.Body
+	}
+
+	return bodies
+}
+
+func TestMigrateIsResumable(t *testing.T) {
+	f := newFixture(t)
+
+	first := f.migrate(t)
+	if first.Messages.Migrated != 3 || first.Users.Migrated != 1 || first.Channels.Migrated != 1 {
+		t.Fatalf("first run reported %+v", first)
+	}
+
+	second := f.migrate(t)
+	if second.Messages.Migrated != 0 || second.Messages.Existing != 3 {
+		t.Fatalf("second run reported %+v, want nothing new", second)
+	}
+}
+
+func TestMigrateDoesNotResendAcceptedMessage(t *testing.T) {
+	f := newFixture(t)
+	f.migrate(t)
+	progress := f.crashBeforeSaving(t)
+
+	resumed := f.migrate(t)
+	if resumed.Messages.Migrated != 0 {
+		t.Errorf("resumed run sent %d messages, want 0", resumed.Messages.Migrated)
+	}
+
+	if bodies := f.conversationBodies(t, progress.ConversationSID); len(bodies) != 3 {
+		t.Errorf("conversation holds %v, want three messages", bodies)
+	}
+}
+
+func TestMigrateResendsLostMessage(t *testing.T) {
+	f := newFixture(t)
+	f.migrate(t)
+	progress := f.crashBeforeSaving(t)
+
+	// Twilio never got the last Message.
+	conv := f.srv.ConversationsClient(f.convSID)
+	messages, err := conv.Messages(context.Background(), progress.ConversationSID)
+	if err != nil {
+		t.Fatalf("Messages: %v", err)
+	}
+	if err := conv.DeleteMessage(context.Background(), progress.ConversationSID, messages[len(messages)-1].SID); err != nil {
+		t.Fatalf("DeleteMessage: %v", err)
+	}
+
+	resumed := f.migrate(t)
+	if resumed.Messages.Migrated != 1 {
+		t.Errorf("resumed run sent %d messages, want 1", resumed.Messages.Migrated)
+	}
+
+	bodies := f.conversationBodies(t, progress.ConversationSID)
+	if len(bodies) != 3 || bodies[2] != "three" {
+		t.Errorf("conversation holds %v, want one, two, three", bodies)
+	}
+}
+
+func TestMigrateChecksOnlyNewestMessageOnResume(t *testing.T) {
+	f := newFixture(t)
+	f.migrate(t)
+	progress := f.crashBeforeSaving(t)
+
+	var queries []string
+	handler := f.srv.Config.Handler
+	f.srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, progress.ConversationSID+"/Messages") && r.Method == http.MethodGet {
+			queries = append(queries, r.URL.RawQuery)
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	f.migrate(t)
+
+	if len(queries) != 1 || queries[0] != "Order=desc&PageSize=1" {
+		t.Errorf("listed the conversation's messages with %q, want a single Order=desc&PageSize=1", queries)
+	}
+}
+
+func TestMigrateDryRunLeavesCheckpoint(t *testing.T) {
+	f := newFixture(t)
+	f.migrate(t)
+	f.crashBeforeSaving(t)
+
+	before, err := os.ReadFile(f.checkpointPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	cp, err := loadCheckpoint(f.checkpointPath, f.chatSID, f.convSID)
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+
+	m := &migrator{chat: f.chat, conv: f.srv.ConversationsClient(f.convSID), cp: cp, dryRun: true}
+	if err := m.run(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	after, err := os.ReadFile(f.checkpointPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if string(before) != string(after) {
+		t.Errorf("dry run rewrote the checkpoint:\n%s\nwas\n%s", after, before)
+	}
+}
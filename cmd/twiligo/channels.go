@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+
+	"github.com/eriktate/twiligo"
+)
+
+var channelsResource = resource{
+	summary: "manage the Channels of a Service",
+	commands: map[string]command{
+		"list":   {"[-limit n]", channelsList},
+		"get":    {"<channel-sid-or-unique-name>", channelsGet},
+		"create": {"[-friendly-name name] [-unique-name name] [-attributes json] [-type public|private]", channelsCreate},
+		"update": {"<channel-sid> [-friendly-name name] [-unique-name name] [-attributes json]", channelsUpdate},
+		"delete": {"<channel-sid> [-yes]", channelsDelete},
+	},
+}
+
+var channelColumns = []column[twiligo.Channel]{
+	{"SID", func(ch twiligo.Channel) string { return ch.SID }},
+	{"UNIQUE NAME", func(ch twiligo.Channel) string { return ch.UniqueName }},
+	{"FRIENDLY NAME", func(ch twiligo.Channel) string { return ch.FriendlyName }},
+	{"TYPE", func(ch twiligo.Channel) string { return ch.Type }},
+	{"MEMBERS", func(ch twiligo.Channel) string { return formatInt(ch.MembersCount) }},
+	{"MESSAGES", func(ch twiligo.Channel) string { return formatInt(ch.MessagesCount) }},
+	{"CREATED", func(ch twiligo.Channel) string { return formatTime(ch.DateCreated) }},
+}
+
+func channelsList(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("channels list")
+	listOpts := listFlags(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	channels, err := client.Channels(ctx, listOpts()...)
+	if err != nil {
+		return err
+	}
+
+	return printList(a.stdout, s.Output, channels, channelColumns)
+}
+
+func channelsGet(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("channels get")
+	pos, err := parseArgs(fs, args, "channel")
+	if err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	channel, err := client.Channel(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, channel, channelColumns)
+}
+
+func channelsCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("channels create")
+	friendlyName := fs.String("friendly-name", "", "name of the Channel")
+	uniqueName := fs.String("unique-name", "", "unique name the Channel can be addressed by")
+	attributes := fs.String("attributes", "", "JSON object of attributes")
+	chanType := fs.String("type", "public", "public or private")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	channel, err := client.CreateChannel(ctx, twiligo.NewChannel(*friendlyName, *uniqueName, *attributes, *chanType))
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, channel, channelColumns)
+}
+
+func channelsUpdate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("channels update")
+	friendlyName := fs.String("friendly-name", "", "name of the Channel")
+	uniqueName := fs.String("unique-name", "", "unique name the Channel can be addressed by")
+	attributes := fs.String("attributes", "", "JSON object of attributes, replacing the current ones")
+	pos, err := parseArgs(fs, args, "channel-sid")
+	if err != nil {
+		return err
+	}
+
+	set := setFlags(fs)
+	update := twiligo.ChannelUpdate{
+		FriendlyName: optional(set, "friendly-name", friendlyName),
+		UniqueName:   optional(set, "unique-name", uniqueName),
+		Attributes:   optional(set, "attributes", attributes),
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	channel, err := client.UpdateChannel(ctx, pos[0], update)
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, channel, channelColumns)
+}
+
+func channelsDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("channels delete")
+	yes := fs.Bool("yes", false, "don't ask for confirmation")
+	pos, err := parseArgs(fs, args, "channel-sid")
+	if err != nil {
+		return err
+	}
+
+	client, _, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	if err := a.confirm(*yes, "Delete channel %s and its messages?", pos[0]); err != nil {
+		return err
+	}
+
+	return client.DeleteChannel(ctx, pos[0])
+}
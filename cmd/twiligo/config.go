@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eriktate/twiligo"
+)
+
+// globalFlags are accepted by every command.
+type globalFlags struct {
+	profile    string
+	configPath string
+	accountSID string
+	authToken  string
+	apiKey     string
+	apiSecret  string
+	serviceSID string
+	baseURL    string
+	output     string
+}
+
+// settings are the resolved credentials and defaults for a command.
+type settings struct {
+	AccountSID string
+	AuthToken  string
+	APIKey     string
+	APISecret  string
+	ServiceSID string
+	BaseURL    string
+	Output     string
+}
+
+// profileKeys maps the keys of a profile to the settings they fill.
+var profileKeys = map[string]func(*settings) *string{
+	"account_sid": func(s *settings) *string { return &s.AccountSID },
+	"auth_token":  func(s *settings) *string { return &s.AuthToken },
+	"api_key":     func(s *settings) *string { return &s.APIKey },
+	"api_secret":  func(s *settings) *string { return &s.APISecret },
+	"service_sid": func(s *settings) *string { return &s.ServiceSID },
+	"base_url":    func(s *settings) *string { return &s.BaseURL },
+	"output":      func(s *settings) *string { return &s.Output },
+}
+
+// flagSet returns a FlagSet for the named command with the global flags registered. Values parsed
+// before the command are kept, so global flags can be given before or after it.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	g := &a.global
+	parsed := *g
+	defer func() { *g = parsed }()
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&g.profile, "profile", "", "profile to read from the config file (default $TWILIGO_PROFILE or \"default\")")
+	fs.StringVar(&g.configPath, "config", "", "config file (default $TWILIGO_CONFIG or "+defaultConfigPath()+")")
+	fs.StringVar(&g.accountSID, "account-sid", "", "Twilio account SID")
+	fs.StringVar(&g.authToken, "auth-token", "", "Twilio auth token")
+	fs.StringVar(&g.apiKey, "api-key", "", "API key SID, used instead of the auth token")
+	fs.StringVar(&g.apiSecret, "api-secret", "", "API key secret")
+	fs.StringVar(&g.serviceSID, "service", "", "SID of the Chat Service to manage")
+	fs.StringVar(&g.baseURL, "base-url", "", "base URL of the Chat API (default "+twiligo.DefaultBaseURL+")")
+	fs.StringVar(&g.output, "output", "", "output format: table, json or yaml")
+	fs.StringVar(&g.output, "o", "", "shorthand for -output")
+
+	return fs
+}
+
+func defaultConfigPath() string {
+	if path := os.Getenv("TWILIGO_CONFIG"); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "twiligo.conf"
+	}
+
+	return filepath.Join(dir, "twiligo", "config")
+}
+
+// settings resolves the settings of a command. Flags win over the environment, which wins over the
+// default profile, but a profile named with -profile or $TWILIGO_PROFILE wins over the environment.
+// Credentials are taken as a whole from the first source that has any, so an account SID from one
+// source is never used with a token from another.
+func (a *app) settings() (settings, error) {
+	g := a.global
+
+	profile := g.profile
+	if profile == "" {
+		profile = os.Getenv("TWILIGO_PROFILE")
+	}
+	explicit := profile != ""
+	if profile == "" {
+		profile = "default"
+	}
+
+	configPath := g.configPath
+	if configPath == "" {
+		configPath = defaultConfigPath()
+	}
+
+	s, err := loadProfile(configPath, profile)
+
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		err = nil
+	}
+
+	if err != nil {
+		return s, err
+	}
+
+	flags := settings{
+		AccountSID: g.accountSID,
+		AuthToken:  g.authToken,
+		APIKey:     g.apiKey,
+		APISecret:  g.apiSecret,
+		ServiceSID: g.serviceSID,
+		BaseURL:    g.baseURL,
+		Output:     g.output,
+	}
+
+	var env settings
+	if !explicit {
+		env = settings{
+			AccountSID: getenv("TWILIO_ACCOUNT_SID", "TWILIO_ACCOUNTSID"),
+			AuthToken:  getenv("TWILIO_AUTH_TOKEN", "TWILIO_AUTHTOKEN"),
+			APIKey:     getenv("TWILIO_API_KEY"),
+			APISecret:  getenv("TWILIO_API_SECRET"),
+			ServiceSID: getenv("TWILIO_SERVICE_SID", "TWILIO_SERVICESID"),
+		}
+	}
+
+	switch {
+	case flags.hasCredentials():
+		s.setCredentials(flags)
+	case env.hasCredentials():
+		s.setCredentials(env)
+	}
+
+	override := func(field *string, values ...string) {
+		for _, value := range values {
+			if value != "" {
+				*field = value
+				return
+			}
+		}
+	}
+
+	override(&s.ServiceSID, flags.ServiceSID, env.ServiceSID)
+	override(&s.BaseURL, flags.BaseURL)
+	override(&s.Output, flags.Output)
+
+	switch s.Output {
+	case "":
+		s.Output = "table"
+	case "table", "json", "yaml":
+	default:
+		return s, usagef("unknown output format %q, use table, json or yaml", s.Output)
+	}
+
+	return s, nil
+}
+
+func (s settings) hasCredentials() bool {
+	return s.AccountSID != "" || s.AuthToken != "" || s.APIKey != "" || s.APISecret != ""
+}
+
+func (s *settings) setCredentials(from settings) {
+	s.AccountSID = from.AccountSID
+	s.AuthToken = from.AuthToken
+	s.APIKey = from.APIKey
+	s.APISecret = from.APISecret
+}
+
+// getenv returns the first of the named environment variables that is set.
+func getenv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// loadProfile reads a profile from an INI style config file with a [name] section per profile and
+// key = value lines. Lines starting with # or ; are comments.
+func loadProfile(path, profile string) (settings, error) {
+	var s settings
+
+	f, err := os.Open(path)
+
+	if err != nil {
+		return s, err
+	}
+	defer f.Close()
+
+	var section string
+	var found bool
+
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			found = found || section == profile
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return s, fmt.Errorf("%s:%d: expected key = value", path, lineNum)
+		}
+
+		if section != profile {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		field, ok := profileKeys[key]
+		if !ok {
+			return s, fmt.Errorf("%s:%d: unknown key %q", path, lineNum, key)
+		}
+
+		*field(&s) = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return s, err
+	}
+
+	if !found {
+		return s, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+
+	return s, nil
+}
+
+// client builds a Client from the resolved settings.
+func (a *app) client(needService bool) (*twiligo.Client, settings, error) {
+	s, err := a.settings()
+
+	if err != nil {
+		return nil, s, err
+	}
+
+	if s.AccountSID == "" {
+		return nil, s, errors.New("no account SID, use -account-sid, TWILIO_ACCOUNT_SID or account_sid in a profile")
+	}
+
+	opts := []twiligo.Option{
+		twiligo.WithRetryPolicy(twiligo.DefaultRetryPolicy),
+		twiligo.WithUserAgent(twiligo.DefaultUserAgent + "-cli"),
+	}
+
+	switch {
+	case s.APIKey != "":
+		if s.APISecret == "" {
+			return nil, s, errors.New("no API secret for the API key, use -api-secret, TWILIO_API_SECRET or api_secret in a profile")
+		}
+		opts = append(opts, twiligo.WithAPIKey(s.APIKey, s.APISecret))
+	case s.AuthToken == "":
+		return nil, s, errors.New("no auth token, use -auth-token, TWILIO_AUTH_TOKEN or auth_token in a profile")
+	}
+
+	if s.BaseURL != "" {
+		opts = append(opts, twiligo.WithBaseURL(s.BaseURL))
+	}
+
+	if needService {
+		if s.ServiceSID == "" {
+			return nil, s, errors.New("no service SID, use -service, TWILIO_SERVICE_SID or service_sid in a profile")
+		}
+		opts = append(opts, twiligo.WithServiceSID(s.ServiceSID))
+	}
+
+	return twiligo.New(s.AccountSID, s.AuthToken, opts...), s, nil
+}
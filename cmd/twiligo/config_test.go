@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfig = `# twiligo profiles
+[default]
+account_sid = ACdefault
+auth_token = default-token
+service_sid = ISdefault
+
+; production uses an API key
+[prod]
+account_sid = "ACprod"
+api_key = SKprod
+api_secret = prod-secret
+output = json
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		profile string
+		want    settings
+		err     string
+	}{
+		{"default", testConfig, "default", settings{AccountSID: "ACdefault", AuthToken: "default-token", ServiceSID: "ISdefault"}, ""},
+		{"quoted values and comments", testConfig, "prod", settings{AccountSID: "ACprod", APIKey: "SKprod", APISecret: "prod-secret", Output: "json"}, ""},
+		{"empty profile", "[empty]\n", "empty", settings{}, ""},
+		{"missing profile", testConfig, "staging", settings{}, `profile "staging" not found`},
+		{"unknown key", "[default]\nregion = us1\n", "default", settings{}, `:2: unknown key "region"`},
+		{"malformed line", "[default]\naccount_sid\n", "default", settings{}, ":2: expected key = value"},
+		{"malformed line in another profile", "[other]\nbroken\n[default]\n", "default", settings{}, ":2: expected key = value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := loadProfile(writeConfig(t, test.config), test.profile)
+
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("loadProfile returned %v, want an error containing %q", err, test.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("loadProfile: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	if _, err := loadProfile(filepath.Join(t.TempDir(), "missing"), "default"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("loadProfile of a missing file returned %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestSettingsPrecedence(t *testing.T) {
+	config := writeConfig(t, testConfig)
+	devEnv := map[string]string{
+		"TWILIO_ACCOUNT_SID": "ACenv",
+		"TWILIO_AUTH_TOKEN":  "env-token",
+		"TWILIO_SERVICE_SID": "ISenv",
+	}
+
+	tests := []struct {
+		name   string
+		global globalFlags
+		env    map[string]string
+		want   settings
+		err    string
+	}{
+		{
+			name: "default profile",
+			want: settings{AccountSID: "ACdefault", AuthToken: "default-token", ServiceSID: "ISdefault", Output: "table"},
+		},
+		{
+			name: "environment over default profile",
+			env:  devEnv,
+			want: settings{AccountSID: "ACenv", AuthToken: "env-token", ServiceSID: "ISenv", Output: "table"},
+		},
+		{
+			name: "legacy environment names",
+			env:  map[string]string{"TWILIO_ACCOUNTSID": "ACenv", "TWILIO_AUTHTOKEN": "env-token"},
+			want: settings{AccountSID: "ACenv", AuthToken: "env-token", ServiceSID: "ISdefault", Output: "table"},
+		},
+		{
+			name:   "explicit profile over environment",
+			global: globalFlags{profile: "prod"},
+			env:    devEnv,
+			want:   settings{AccountSID: "ACprod", APIKey: "SKprod", APISecret: "prod-secret", Output: "json"},
+		},
+		{
+			name: "profile from TWILIGO_PROFILE over environment",
+			env:  map[string]string{"TWILIGO_PROFILE": "prod", "TWILIO_AUTH_TOKEN": "env-token"},
+			want: settings{AccountSID: "ACprod", APIKey: "SKprod", APISecret: "prod-secret", Output: "json"},
+		},
+		{
+			name:   "flags over explicit profile",
+			global: globalFlags{profile: "prod", accountSID: "ACflag", authToken: "flag-token", output: "yaml"},
+			want:   settings{AccountSID: "ACflag", AuthToken: "flag-token", Output: "yaml"},
+		},
+		{
+			name: "environment token never mixed with profile account",
+			env:  map[string]string{"TWILIO_AUTH_TOKEN": "env-token"},
+			want: settings{AuthToken: "env-token", ServiceSID: "ISdefault", Output: "table"},
+		},
+		{
+			name:   "flag service over environment",
+			global: globalFlags{serviceSID: "ISflag"},
+			env:    devEnv,
+			want:   settings{AccountSID: "ACenv", AuthToken: "env-token", ServiceSID: "ISflag", Output: "table"},
+		},
+		{
+			name:   "missing explicit profile",
+			global: globalFlags{profile: "staging"},
+			err:    `profile "staging" not found`,
+		},
+		{
+			name:   "unknown output",
+			global: globalFlags{output: "xml"},
+			err:    `unknown output format "xml"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, name := range []string{"TWILIGO_PROFILE", "TWILIO_ACCOUNT_SID", "TWILIO_ACCOUNTSID", "TWILIO_AUTH_TOKEN",
+				"TWILIO_AUTHTOKEN", "TWILIO_API_KEY", "TWILIO_API_SECRET", "TWILIO_SERVICE_SID", "TWILIO_SERVICESID"} {
+				t.Setenv(name, test.env[name])
+			}
+
+			global := test.global
+			global.configPath = config
+			a := &app{global: global, stderr: io.Discard}
+
+			got, err := a.settings()
+
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("settings returned %v, want an error containing %q", err, test.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("settings: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSettingsWithoutConfigFile(t *testing.T) {
+	t.Setenv("TWILIGO_PROFILE", "")
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	a := &app{global: globalFlags{configPath: missing, accountSID: "AC123", authToken: "token"}}
+	if _, err := a.settings(); err != nil {
+		t.Errorf("settings without a config file: %v", err)
+	}
+
+	a.global.profile = "prod"
+	if _, err := a.settings(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("settings with an explicit profile and no config file returned %v, want fs.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,267 @@
+// Command twiligo manages Twilio Programmable Chat from the command line.
+//
+//	twiligo [global flags] <resource> <command> [flags] [args]
+//
+// The resources are services, channels, messages and users, and each supports the commands list,
+// get, create (send for messages), update and delete. Run "twiligo <resource>" for details.
+//
+// Credentials are read from flags, then the environment (TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN,
+// TWILIO_API_KEY, TWILIO_API_SECRET and TWILIO_SERVICE_SID), then a profile in the config file. A
+// profile chosen with -profile or TWILIGO_PROFILE ignores the environment, and credentials are
+// never mixed between sources.
+// Output is a table unless -output json or -output yaml is given.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+)
+
+// action runs a single command, e.g. "channels list", with the arguments that follow it.
+type action func(ctx context.Context, a *app, args []string) error
+
+// resource is a group of commands, e.g. "channels".
+type resource struct {
+	summary  string
+	commands map[string]command
+}
+
+type command struct {
+	usage string // The arguments and flags of the command, shown in help.
+	run   action
+}
+
+var resources = map[string]resource{
+	"services": servicesResource,
+	"channels": channelsResource,
+	"messages": messagesResource,
+	"users":    usersResource,
+}
+
+// usageError is returned for invalid invocations, which exit with status 2 rather than 1. An empty
+// message means the problem was already reported, as the flag package does for bad flags.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return usageError{fmt.Sprintf(format, args...)}
+}
+
+// app holds the global flags and streams shared by every command.
+type app struct {
+	global globalFlags
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	err := a.run(ctx, os.Args[1:])
+
+	var usage usageError
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+	case errors.As(err, &usage):
+		if usage.msg != "" {
+			fmt.Fprintf(a.stderr, "twiligo: %s\n", err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintf(a.stderr, "twiligo: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	fs := a.flagSet("twiligo")
+	fs.Usage = a.usage
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		a.usage()
+		return usagef("missing resource")
+	}
+
+	res, ok := resources[args[0]]
+	if !ok {
+		a.usage()
+		return usagef("unknown resource %q", args[0])
+	}
+
+	if len(args) == 1 || args[1] == "help" || args[1] == "-h" || args[1] == "--help" {
+		a.resourceUsage(args[0], res)
+		if len(args) == 1 {
+			return usagef("missing command for %s", args[0])
+		}
+		return nil
+	}
+
+	cmd, ok := res.commands[args[1]]
+	if !ok {
+		a.resourceUsage(args[0], res)
+		return usagef("unknown command %q for %s", args[1], args[0])
+	}
+
+	return cmd.run(ctx, a, args[2:])
+}
+
+func (a *app) usage() {
+	fmt.Fprint(a.stderr, `Usage: twiligo [global flags] <resource> <command> [flags] [args]
+
+Resources:
+`)
+	for _, name := range sortedKeys(resources) {
+		fmt.Fprintf(a.stderr, "  %-10s %s\n", name, resources[name].summary)
+	}
+
+	fmt.Fprint(a.stderr, `
+Global flags, accepted before or after the command:
+`)
+	fs := a.flagSet("twiligo")
+	fs.SetOutput(a.stderr)
+	fs.PrintDefaults()
+
+	fmt.Fprintf(a.stderr, `
+Profiles are read from %s, e.g.
+
+  [default]
+  account_sid = AC...
+  auth_token = ...
+  service_sid = IS...
+`, defaultConfigPath())
+}
+
+func (a *app) resourceUsage(name string, res resource) {
+	fmt.Fprintf(a.stderr, "Usage of twiligo %s:\n", name)
+	for _, cmd := range sortedKeys(res.commands) {
+		fmt.Fprintf(a.stderr, "  twiligo %s %s %s\n", name, cmd, res.commands[cmd].usage)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// parseArgs parses flags that may be interleaved with positional arguments, which the flag package
+// doesn't support on its own, and checks the number of positional arguments. Everything after "--"
+// is positional.
+func parseArgs(fs *flag.FlagSet, args []string, names ...string) ([]string, error) {
+	var rest []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, flagError(err)
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	positional = append(positional, rest...)
+
+	if len(positional) != len(names) {
+		want := "no arguments"
+		if len(names) > 0 {
+			want = "<" + strings.Join(names, "> <") + ">"
+		}
+		return nil, usagef("%s expects %s, got %d arguments", fs.Name(), want, len(positional))
+	}
+
+	return positional, nil
+}
+
+// flagError converts an error from FlagSet.Parse, which has already printed it along with the
+// usage, so it isn't printed twice.
+func flagError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+
+	return usageError{}
+}
+
+// setFlags returns the names of the flags that were given explicitly, which is how update commands
+// tell "leave unchanged" apart from "set to the zero value".
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	return set
+}
+
+// optional returns v when the named flag was given and nil otherwise, for filling update params.
+func optional[T any](set map[string]bool, name string, v *T) *T {
+	if set[name] {
+		return v
+	}
+
+	return nil
+}
+
+// stringList is a flag that may be given multiple times.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// confirm asks before deleting anything, unless -yes was given.
+func (a *app) confirm(yes bool, format string, args ...any) error {
+	if yes {
+		return nil
+	}
+
+	fmt.Fprintf(a.stderr, format+" [y/N] ", args...)
+
+	var answer string
+	fmt.Fscanln(a.stdin, &answer)
+	if answer != "y" && answer != "Y" && answer != "yes" {
+		return errors.New("aborted")
+	}
+
+	return nil
+}
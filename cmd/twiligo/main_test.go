@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		names      []string
+		positional []string
+		limit      int
+		output     string
+		usage      bool
+	}{
+		{"flags after arguments", []string{"CH123", "-limit", "5"}, []string{"channel"}, []string{"CH123"}, 5, "", false},
+		{"flags around arguments", []string{"-o", "json", "CH123", "-limit=2", "hello"}, []string{"channel", "body"}, []string{"CH123", "hello"}, 2, "json", false},
+		{"everything after -- is positional", []string{"CH123", "--", "-limit"}, []string{"channel", "body"}, []string{"CH123", "-limit"}, 0, "", false},
+		{"no arguments", nil, nil, nil, 0, "", false},
+		{"too few arguments", []string{"-limit", "1"}, []string{"channel"}, nil, 0, "", true},
+		{"too many arguments", []string{"CH123", "extra"}, []string{"channel"}, nil, 0, "", true},
+		{"unknown flag", []string{"-bogus"}, nil, nil, 0, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &app{stderr: io.Discard}
+			fs := a.flagSet("channels test")
+			limit := fs.Int("limit", 0, "")
+
+			positional, err := parseArgs(fs, test.args, test.names...)
+
+			if test.usage {
+				var usage usageError
+				if !errors.As(err, &usage) {
+					t.Fatalf("parseArgs returned %v, want a usage error", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseArgs: %v", err)
+			}
+
+			if !slices.Equal(positional, test.positional) || *limit != test.limit || a.global.output != test.output {
+				t.Errorf("got %q, limit %d and output %q, want %q, %d and %q", positional, *limit, a.global.output, test.positional, test.limit, test.output)
+			}
+		})
+	}
+}
+
+func TestParseArgsHelp(t *testing.T) {
+	a := &app{stderr: io.Discard}
+	if _, err := parseArgs(a.flagSet("channels list"), []string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("parseArgs(-h) returned %v, want flag.ErrHelp", err)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+
+	"github.com/eriktate/twiligo"
+)
+
+var messagesResource = resource{
+	summary: "manage the Messages of a Channel",
+	commands: map[string]command{
+		"list":   {"<channel> [-limit n]", messagesList},
+		"get":    {"<channel> <message-sid>", messagesGet},
+		"send":   {"<channel> -body text [-from identity] [-attributes json]", messagesSend},
+		"update": {"<channel> <message-sid> [-body text] [-attributes json]", messagesUpdate},
+		"delete": {"<channel> <message-sid> [-yes]", messagesDelete},
+	},
+}
+
+var messageColumns = []column[twiligo.Message]{
+	{"INDEX", func(m twiligo.Message) string { return formatInt(m.Index) }},
+	{"SID", func(m twiligo.Message) string { return m.SID }},
+	{"FROM", func(m twiligo.Message) string { return m.From }},
+	{"CREATED", func(m twiligo.Message) string { return formatTime(m.DateCreated) }},
+	{"BODY", func(m twiligo.Message) string { return m.Body }},
+}
+
+func messagesList(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("messages list")
+	listOpts := listFlags(fs)
+	pos, err := parseArgs(fs, args, "channel")
+	if err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	messages, err := client.Messages(ctx, pos[0], listOpts()...)
+	if err != nil {
+		return err
+	}
+
+	return printList(a.stdout, s.Output, messages, messageColumns)
+}
+
+func messagesGet(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("messages get")
+	pos, err := parseArgs(fs, args, "channel", "message-sid")
+	if err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	message, err := client.Message(ctx, pos[0], pos[1])
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, message, messageColumns)
+}
+
+func messagesSend(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("messages send")
+	body := fs.String("body", "", "text of the Message (required)")
+	from := fs.String("from", "", "identity of the author (default system)")
+	attributes := fs.String("attributes", "", "JSON object of attributes")
+	pos, err := parseArgs(fs, args, "channel")
+	if err != nil {
+		return err
+	}
+
+	if *body == "" {
+		return usagef("messages send requires -body")
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	message, err := client.SendMessage(ctx, pos[0], twiligo.Message{Body: *body, From: *from, Attributes: *attributes})
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, message, messageColumns)
+}
+
+func messagesUpdate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("messages update")
+	body := fs.String("body", "", "text of the Message")
+	attributes := fs.String("attributes", "", "JSON object of attributes, replacing the current ones")
+	pos, err := parseArgs(fs, args, "channel", "message-sid")
+	if err != nil {
+		return err
+	}
+
+	set := setFlags(fs)
+	update := twiligo.MessageUpdate{
+		Body:       optional(set, "body", body),
+		Attributes: optional(set, "attributes", attributes),
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	message, err := client.UpdateMessage(ctx, pos[0], pos[1], update)
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, message, messageColumns)
+}
+
+func messagesDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("messages delete")
+	yes := fs.Bool("yes", false, "don't ask for confirmation")
+	pos, err := parseArgs(fs, args, "channel", "message-sid")
+	if err != nil {
+		return err
+	}
+
+	client, _, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	if err := a.confirm(*yes, "Delete message %s?", pos[1]); err != nil {
+		return err
+	}
+
+	return client.DeleteMessage(ctx, pos[0], pos[1])
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// column is a column of table output.
+type column[T any] struct {
+	header string
+	value  func(T) string
+}
+
+// printList writes items in the requested format. Tables show the given columns, while JSON and
+// YAML show every field.
+func printList[T any](w io.Writer, format string, items []T, columns []column[T]) error {
+	switch format {
+	case "json":
+		if items == nil {
+			items = []T{}
+		}
+		return printJSON(w, items)
+	case "yaml":
+		if items == nil {
+			items = []T{}
+		}
+		return encodeYAML(w, items)
+	}
+
+	return printTable(w, items, columns)
+}
+
+// printOne writes a single item in the requested format.
+func printOne[T any](w io.Writer, format string, item T, columns []column[T]) error {
+	switch format {
+	case "json":
+		return printJSON(w, item)
+	case "yaml":
+		return encodeYAML(w, item)
+	}
+
+	return printList(w, format, []T{item}, columns)
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+func printTable[T any](w io.Writer, items []T, columns []column[T]) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, item := range items {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			values[i] = cell(col.value(item))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// maxCellWidth keeps long values such as message bodies from blowing up tables.
+const maxCellWidth = 60
+
+// cell makes a value fit on a single line of a table.
+func cell(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return "-"
+	}
+
+	if runes := []rune(value); len(runes) > maxCellWidth {
+		return string(runes[:maxCellWidth-3]) + "..."
+	}
+
+	return value
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func formatInt(n int) string {
+	return strconv.Itoa(n)
+}
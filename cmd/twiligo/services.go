@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/eriktate/twiligo"
+)
+
+var servicesResource = resource{
+	summary: "manage the account's Chat Services",
+	commands: map[string]command{
+		"list":   {"[-limit n]", servicesList},
+		"get":    {"<service-sid>", servicesGet},
+		"create": {"-friendly-name name", servicesCreate},
+		"update": {"<service-sid> [-friendly-name name] [-default-service-role sid] [-default-channel-role sid] [-default-channel-creator-role sid] [-read-status-enabled bool] [-reachability-enabled bool] [-typing-indicator-timeout seconds] [-consumption-report-interval seconds] [-pre-webhook-url url] [-post-webhook-url url] [-webhook-method method] [-webhook-filter event]...", servicesUpdate},
+		"delete": {"<service-sid> [-yes]", servicesDelete},
+	},
+}
+
+var serviceColumns = []column[twiligo.Service]{
+	{"SID", func(s twiligo.Service) string { return s.SID }},
+	{"FRIENDLY NAME", func(s twiligo.Service) string { return s.FriendlyName }},
+	{"CREATED", func(s twiligo.Service) string { return formatTime(s.DateCreated) }},
+	{"UPDATED", func(s twiligo.Service) string { return formatTime(s.DateUpdated) }},
+}
+
+// listFlags registers the paging flags shared by list commands.
+func listFlags(fs *flag.FlagSet) func() []twiligo.ListOption {
+	limit := fs.Int("limit", 0, "stop after this many items (default all)")
+	pageSize := fs.Int("page-size", 0, "items requested per page, up to 100")
+
+	return func() []twiligo.ListOption {
+		var opts []twiligo.ListOption
+		if *limit > 0 {
+			opts = append(opts, twiligo.Limit(*limit))
+		}
+		if *pageSize > 0 {
+			opts = append(opts, twiligo.PageSize(*pageSize))
+		}
+
+		return opts
+	}
+}
+
+func servicesList(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("services list")
+	listOpts := listFlags(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, s, err := a.client(false)
+	if err != nil {
+		return err
+	}
+
+	services, err := client.Services(ctx, listOpts()...)
+	if err != nil {
+		return err
+	}
+
+	return printList(a.stdout, s.Output, services, serviceColumns)
+}
+
+func servicesGet(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("services get")
+	pos, err := parseArgs(fs, args, "service-sid")
+	if err != nil {
+		return err
+	}
+
+	client, s, err := a.client(false)
+	if err != nil {
+		return err
+	}
+
+	service, err := client.Service(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, service, serviceColumns)
+}
+
+func servicesCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("services create")
+	friendlyName := fs.String("friendly-name", "", "name of the Service (required)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if *friendlyName == "" {
+		return usagef("services create requires -friendly-name")
+	}
+
+	client, s, err := a.client(false)
+	if err != nil {
+		return err
+	}
+
+	service, err := client.CreateService(ctx, twiligo.Service{FriendlyName: *friendlyName})
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, service, serviceColumns)
+}
+
+func servicesUpdate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("services update")
+	friendlyName := fs.String("friendly-name", "", "name of the Service")
+	defaultServiceRole := fs.String("default-service-role", "", "SID of the Role given to new Users")
+	defaultChannelRole := fs.String("default-channel-role", "", "SID of the Role given to new Members")
+	defaultChannelCreatorRole := fs.String("default-channel-creator-role", "", "SID of the Role given to Channel creators")
+	readStatusEnabled := fs.Bool("read-status-enabled", false, "whether read status is tracked")
+	reachabilityEnabled := fs.Bool("reachability-enabled", false, "whether User reachability is tracked")
+	typingIndicatorTimeout := fs.Int("typing-indicator-timeout", 0, "seconds a typing indicator lasts")
+	consumptionReportInterval := fs.Int("consumption-report-interval", 0, "seconds between consumption reports")
+	preWebhookURL := fs.String("pre-webhook-url", "", "URL called before events")
+	postWebhookURL := fs.String("post-webhook-url", "", "URL called after events")
+	webhookMethod := fs.String("webhook-method", "", "HTTP method of webhooks")
+	var webhookFilters stringList
+	fs.Var(&webhookFilters, "webhook-filter", "event sent to webhooks, may be repeated and replaces the current filters")
+
+	pos, err := parseArgs(fs, args, "service-sid")
+	if err != nil {
+		return err
+	}
+
+	set := setFlags(fs)
+	update := twiligo.ServiceUpdate{
+		FriendlyName:                 optional(set, "friendly-name", friendlyName),
+		DefaultServiceRoleSID:        optional(set, "default-service-role", defaultServiceRole),
+		DefaultChannelRoleSID:        optional(set, "default-channel-role", defaultChannelRole),
+		DefaultChannelCreatorRoleSID: optional(set, "default-channel-creator-role", defaultChannelCreatorRole),
+		ReadStatusEnabled:            optional(set, "read-status-enabled", readStatusEnabled),
+		ReachabilityEnabled:          optional(set, "reachability-enabled", reachabilityEnabled),
+		TypingIndicatorTimeout:       optional(set, "typing-indicator-timeout", typingIndicatorTimeout),
+		ConsumptionReportInterval:    optional(set, "consumption-report-interval", consumptionReportInterval),
+		PreWebhookURL:                optional(set, "pre-webhook-url", preWebhookURL),
+		PostWebhookURL:               optional(set, "post-webhook-url", postWebhookURL),
+		WebhookMethod:                optional(set, "webhook-method", webhookMethod),
+		WebhookFilters:               webhookFilters,
+	}
+
+	client, s, err := a.client(false)
+	if err != nil {
+		return err
+	}
+
+	service, err := client.UpdateService(ctx, pos[0], update)
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, service, serviceColumns)
+}
+
+func servicesDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("services delete")
+	yes := fs.Bool("yes", false, "don't ask for confirmation")
+	pos, err := parseArgs(fs, args, "service-sid")
+	if err != nil {
+		return err
+	}
+
+	client, _, err := a.client(false)
+	if err != nil {
+		return err
+	}
+
+	if err := a.confirm(*yes, "Delete service %s and everything in it?", pos[0]); err != nil {
+		return err
+	}
+
+	return client.DeleteService(ctx, pos[0])
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+
+	"github.com/eriktate/twiligo"
+)
+
+var usersResource = resource{
+	summary: "manage the Users of a Service",
+	commands: map[string]command{
+		"list":   {"[-limit n]", usersList},
+		"get":    {"<user-sid-or-identity>", usersGet},
+		"create": {"<identity> [-friendly-name name] [-attributes json] [-role sid]", usersCreate},
+		"update": {"<user-sid> [-friendly-name name] [-attributes json] [-role sid]", usersUpdate},
+		"delete": {"<user-sid> [-yes]", usersDelete},
+	},
+}
+
+var userColumns = []column[twiligo.User]{
+	{"SID", func(u twiligo.User) string { return u.SID }},
+	{"IDENTITY", func(u twiligo.User) string { return u.Identity }},
+	{"FRIENDLY NAME", func(u twiligo.User) string { return u.FriendlyName }},
+	{"ROLE", func(u twiligo.User) string { return u.RoleSID }},
+	{"CHANNELS", func(u twiligo.User) string { return formatInt(u.JoinedChannelsCount) }},
+	{"CREATED", func(u twiligo.User) string { return formatTime(u.DateCreated) }},
+}
+
+func usersList(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("users list")
+	listOpts := listFlags(fs)
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.Users(ctx, listOpts()...)
+	if err != nil {
+		return err
+	}
+
+	return printList(a.stdout, s.Output, users, userColumns)
+}
+
+func usersGet(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("users get")
+	pos, err := parseArgs(fs, args, "user")
+	if err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	user, err := client.User(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, user, userColumns)
+}
+
+func usersCreate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("users create")
+	friendlyName := fs.String("friendly-name", "", "display name of the User")
+	attributes := fs.String("attributes", "", "JSON object of attributes")
+	role := fs.String("role", "", "SID of the User's Role (default the Service's)")
+	pos, err := parseArgs(fs, args, "identity")
+	if err != nil {
+		return err
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	user, err := client.CreateUser(ctx, twiligo.User{
+		Identity:     pos[0],
+		FriendlyName: *friendlyName,
+		Attributes:   *attributes,
+		RoleSID:      *role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, user, userColumns)
+}
+
+func usersUpdate(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("users update")
+	friendlyName := fs.String("friendly-name", "", "display name of the User")
+	attributes := fs.String("attributes", "", "JSON object of attributes, replacing the current ones")
+	role := fs.String("role", "", "SID of the User's Role")
+	pos, err := parseArgs(fs, args, "user-sid")
+	if err != nil {
+		return err
+	}
+
+	set := setFlags(fs)
+	update := twiligo.UserUpdate{
+		FriendlyName: optional(set, "friendly-name", friendlyName),
+		Attributes:   optional(set, "attributes", attributes),
+		RoleSID:      optional(set, "role", role),
+	}
+
+	client, s, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	user, err := client.UpdateUser(ctx, pos[0], update)
+	if err != nil {
+		return err
+	}
+
+	return printOne(a.stdout, s.Output, user, userColumns)
+}
+
+func usersDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.flagSet("users delete")
+	yes := fs.Bool("yes", false, "don't ask for confirmation")
+	pos, err := parseArgs(fs, args, "user-sid")
+	if err != nil {
+		return err
+	}
+
+	client, _, err := a.client(true)
+	if err != nil {
+		return err
+	}
+
+	if err := a.confirm(*yes, "Delete user %s?", pos[0]); err != nil {
+		return err
+	}
+
+	return client.DeleteUser(ctx, pos[0])
+}
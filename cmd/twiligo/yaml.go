@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// orderedMap is a JSON object that keeps the order of its keys, so YAML output lists fields in the
+// same order as JSON output.
+type orderedMap struct {
+	keys   []string
+	values []any
+}
+
+// encodeYAML writes v as a YAML document. v is encoded through encoding/json first, so field names
+// and omitempty follow the json struct tags.
+func encodeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	node, err := decodeNode(dec)
+
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeBlock(&b, node, 0)
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// decodeNode decodes the next JSON value into an *orderedMap, []any or scalar.
+func decodeNode(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		m := &orderedMap{}
+		for dec.More() {
+			key, err := dec.Token()
+
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeNode(dec)
+
+			if err != nil {
+				return nil, err
+			}
+
+			m.keys = append(m.keys, key.(string))
+			m.values = append(m.values, value)
+		}
+
+		_, err := dec.Token()
+		return m, err
+	case json.Delim('['):
+		list := []any{}
+		for dec.More() {
+			value, err := decodeNode(dec)
+
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+
+		_, err := dec.Token()
+		return list, err
+	}
+
+	return tok, nil
+}
+
+// writeBlock writes a node starting on a new line at the given indentation level.
+func writeBlock(b *strings.Builder, node any, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch n := node.(type) {
+	case *orderedMap:
+		if len(n.keys) == 0 {
+			b.WriteString(pad + "{}\n")
+			return
+		}
+
+		for i, key := range n.keys {
+			b.WriteString(pad + yamlScalar(key) + ":")
+			writeValue(b, n.values[i], indent)
+		}
+	case []any:
+		if len(n) == 0 {
+			b.WriteString(pad + "[]\n")
+			return
+		}
+
+		for _, item := range n {
+			b.WriteString(pad + "-")
+			writeItem(b, item, indent)
+		}
+	default:
+		b.WriteString(pad + yamlScalar(n) + "\n")
+	}
+}
+
+// writeValue writes the value of a mapping key, right after its colon.
+func writeValue(b *strings.Builder, node any, indent int) {
+	switch n := node.(type) {
+	case *orderedMap:
+		if len(n.keys) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteString("\n")
+		writeBlock(b, n, indent+1)
+	case []any:
+		if len(n) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n")
+		writeBlock(b, n, indent+1)
+	default:
+		b.WriteString(" " + yamlScalar(n) + "\n")
+	}
+}
+
+// writeItem writes a sequence item, right after its dash. Collections start on the dash's line.
+func writeItem(b *strings.Builder, node any, indent int) {
+	switch n := node.(type) {
+	case *orderedMap, []any:
+		var nested strings.Builder
+		writeBlock(&nested, n, indent+1)
+		b.WriteString(" " + strings.TrimPrefix(nested.String(), strings.Repeat("  ", indent+1)))
+	default:
+		b.WriteString(" " + yamlScalar(n) + "\n")
+	}
+}
+
+// yamlScalar formats a JSON scalar, quoting strings that YAML would otherwise read as something
+// else.
+func yamlScalar(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(s)
+	case json.Number:
+		return s.String()
+	case string:
+		if needsQuotes(s) {
+			return strconv.Quote(s)
+		}
+		return s
+	}
+
+	return fmt.Sprint(v)
+}
+
+func needsQuotes(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return true
+	}
+
+	switch strings.ToLower(s) {
+	case "~", "null", "true", "false", "yes", "no", "y", "n", "on", "off":
+		return true
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+
+	// YAML reads many more things as numbers or timestamps than Go does, e.g. 0o17, .inf, 1_000,
+	// 1:20 or 2024-01-02, so anything that starts out like one is quoted.
+	if strings.ContainsAny(s[:1], "0123456789+.") {
+		return true
+	}
+
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+
+	for _, r := range s {
+		if r < ' ' || r == 0x7f || r == '\ufeff' {
+			return true
+		}
+	}
+
+	return false
+}
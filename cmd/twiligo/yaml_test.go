@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodeYAML(t *testing.T) {
+	type nested struct {
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Empty *string  `json:"empty,omitempty"`
+	}
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"scalars", map[string]any{"a": 1, "b": true, "c": nil}, "a: 1\nb: true\nc: null\n"},
+		{"field order", nested{Name: "general", Tags: []string{"x"}}, "name: general\ntags:\n  - x\n"},
+		{"empty collections", map[string]any{"list": []int{}, "map": map[string]int{}}, "list: []\nmap: {}\n"},
+		{"list of maps", []nested{{Name: "a"}, {Name: "b", Tags: []string{"x", "y"}}}, "- name: a\n  tags: null\n- name: b\n  tags:\n    - x\n    - \"y\"\n"},
+		{"nested lists", [][]int{{1, 2}, {}}, "- - 1\n  - 2\n- []\n"},
+		{"empty top level list", []string{}, "[]\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := encodeYAML(&b, test.v); err != nil {
+				t.Fatalf("encodeYAML: %v", err)
+			}
+
+			if b.String() != test.want {
+				t.Errorf("got\n%s\nwant\n%s", b.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestYAMLScalarQuoting(t *testing.T) {
+	tests := []struct {
+		in     string
+		quoted bool
+	}{
+		{"general", false},
+		{"CH0123456789abcdef", false},
+		{"hello world", false},
+		{"y", true},
+		{"", true},
+		{" padded", true},
+		{"true", true},
+		{"No", true},
+		{"~", true},
+		{"null", true},
+		{"42", true},
+		{"-1.5e3", true},
+		{"0x1F", true},
+		{"0o17", true},
+		{"0b101", true},
+		{"1_000", true},
+		{".inf", true},
+		{"-.Inf", true},
+		{".NaN", true},
+		{"NaN", true},
+		{"1:20", true},
+		{"2024-01-02T03:04:05Z", true},
+		{"key: value", true},
+		{"note #1", true},
+		{"{json}", true},
+		{"*alias", true},
+		{"line\nbreak", true},
+	}
+
+	for _, test := range tests {
+		got := yamlScalar(test.in)
+		if quoted := got != test.in; quoted != test.quoted {
+			t.Errorf("yamlScalar(%q) = %s, want quoted %t", test.in, got, test.quoted)
+		}
+	}
+}
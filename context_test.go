@@ -0,0 +1,62 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eriktate/twiligo"
+)
+
+func TestContextDeadlineAbortsRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	client := twiligo.NewClient(srv.URL, "AC123", "IS0123456789abcdef0123456789abcdef", "token")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Channels(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Channels returned %v, want context.DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Channels took %s to give up", elapsed)
+	}
+}
+
+func TestContextCancelAbortsRetrySleep(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := twiligo.NewClient(srv.URL, "AC123", "IS0123456789abcdef0123456789abcdef", "token",
+		twiligo.WithRetryPolicy(twiligo.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Hour, MaxBackoff: time.Hour}))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.Channels(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Channels returned %v, want context.Canceled", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second || requests != 1 {
+		t.Errorf("Channels gave up after %s and %d requests, want one request and no long sleep", elapsed, requests)
+	}
+}
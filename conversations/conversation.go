@@ -0,0 +1,195 @@
+package conversations
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// State is the lifecycle state of a Conversation.
+type State string
+
+// The states a Conversation can be in.
+const (
+	StateActive   State = "active"
+	StateInactive State = "inactive"
+	StateClosed   State = "closed"
+)
+
+// Conversation is the structured representation of a Twilio Conversation, the counterpart of a Chat
+// Channel.
+type Conversation struct {
+	SID                 string            `json:"sid,omitempty"`
+	AccountSID          string            `json:"account_sid,omitempty"`
+	ChatServiceSID      string            `json:"chat_service_sid,omitempty"`
+	MessagingServiceSID string            `json:"messaging_service_sid,omitempty"`
+	FriendlyName        string            `json:"friendly_name,omitempty"`
+	UniqueName          string            `json:"unique_name,omitempty"`
+	Attributes          string            `json:"attributes,omitempty"` // A JSON object encoded as a string.
+	State               State             `json:"state,omitempty"`
+	Timers              Timers            `json:"timers,omitempty"`
+	DateCreated         *time.Time        `json:"date_created,omitempty"`
+	DateUpdated         *time.Time        `json:"date_updated,omitempty"`
+	URL                 string            `json:"url,omitempty"`
+	Links               ConversationLinks `json:"links,omitempty"`
+}
+
+// Timers is the structured representation of when a Conversation will change State on its own.
+type Timers struct {
+	DateInactive *time.Time `json:"date_inactive,omitempty"`
+	DateClosed   *time.Time `json:"date_closed,omitempty"`
+}
+
+// ConversationLinks is the structured representation of the links within a Conversation.
+type ConversationLinks struct {
+	Participants string `json:"participants,omitempty"`
+	Messages     string `json:"messages,omitempty"`
+	Webhooks     string `json:"webhooks,omitempty"`
+}
+
+// ConversationUpdate holds the fields to change on a Conversation. Nil fields are left as they are.
+type ConversationUpdate struct {
+	FriendlyName        *string
+	UniqueName          *string
+	Attributes          *string
+	MessagingServiceSID *string
+	State               *State
+	DateCreated         *time.Time
+	DateUpdated         *time.Time
+}
+
+// Conversation retrieves a single Conversation by SID or unique name.
+func (c *Client) Conversation(ctx context.Context, id string) (Conversation, error) {
+	var conversation Conversation
+
+	resourceURL, err := c.resourceURL("Conversations", id)
+
+	if err != nil {
+		return conversation, err
+	}
+
+	data, err := c.get(ctx, resourceURL)
+
+	if err != nil {
+		return conversation, err
+	}
+
+	if err := json.Unmarshal(data, &conversation); err != nil {
+		return conversation, err
+	}
+
+	return conversation, nil
+}
+
+// Conversations retrieves every Conversation, following pagination.
+func (c *Client) Conversations(ctx context.Context, opts ...twiligo.ListOption) ([]Conversation, error) {
+	listURL, err := c.resourceURL("Conversations")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return twiligo.List[Conversation](ctx, c.api, listURL, opts...)
+}
+
+// IterConversations lazily iterates over the Conversations, fetching pages as needed.
+func (c *Client) IterConversations(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[Conversation, error] {
+	listURL, err := c.resourceURL("Conversations")
+
+	if err != nil {
+		return rest.Failed[Conversation](err)
+	}
+
+	return twiligo.Iterate[Conversation](ctx, c.api, listURL, opts...)
+}
+
+// CreateConversation creates a new Conversation. Its DateCreated and DateUpdated are sent when set,
+// so Conversations imported from elsewhere keep their original timestamps.
+func (c *Client) CreateConversation(ctx context.Context, conversation Conversation) (Conversation, error) {
+	var createdConversation Conversation
+
+	if err := rest.ValidateAttributes(conversation.Attributes); err != nil {
+		return createdConversation, err
+	}
+
+	form := url.Values{}
+	rest.AddString(form, "FriendlyName", conversation.FriendlyName)
+	rest.AddString(form, "UniqueName", conversation.UniqueName)
+	rest.AddString(form, "Attributes", conversation.Attributes)
+	rest.AddString(form, "MessagingServiceSid", conversation.MessagingServiceSID)
+	rest.AddString(form, "State", string(conversation.State))
+	rest.AddTime(form, "DateCreated", conversation.DateCreated)
+	rest.AddTime(form, "DateUpdated", conversation.DateUpdated)
+
+	resourceURL, err := c.resourceURL("Conversations")
+
+	if err != nil {
+		return createdConversation, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return createdConversation, err
+	}
+
+	if err := json.Unmarshal(data, &createdConversation); err != nil {
+		return createdConversation, err
+	}
+
+	return createdConversation, nil
+}
+
+// UpdateConversation changes a Conversation. Only the non-nil fields of update are sent.
+func (c *Client) UpdateConversation(ctx context.Context, id string, update ConversationUpdate) (Conversation, error) {
+	var updatedConversation Conversation
+
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedConversation, err
+	}
+
+	form := url.Values{}
+	rest.SetString(form, "FriendlyName", update.FriendlyName)
+	rest.SetString(form, "UniqueName", update.UniqueName)
+	rest.SetString(form, "Attributes", update.Attributes)
+	rest.SetString(form, "MessagingServiceSid", update.MessagingServiceSID)
+	if update.State != nil {
+		form.Set("State", string(*update.State))
+	}
+	rest.AddTime(form, "DateCreated", update.DateCreated)
+	rest.AddTime(form, "DateUpdated", update.DateUpdated)
+
+	resourceURL, err := c.resourceURL("Conversations", id)
+
+	if err != nil {
+		return updatedConversation, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return updatedConversation, err
+	}
+
+	if err := json.Unmarshal(data, &updatedConversation); err != nil {
+		return updatedConversation, err
+	}
+
+	return updatedConversation, nil
+}
+
+// DeleteConversation deletes a Conversation along with its Participants and Messages.
+func (c *Client) DeleteConversation(ctx context.Context, id string) error {
+	resourceURL, err := c.resourceURL("Conversations", id)
+
+	if err != nil {
+		return err
+	}
+
+	return c.delete(ctx, resourceURL)
+}
@@ -0,0 +1,122 @@
+// Package conversations is a client for the Twilio Conversations API, the successor of Programmable
+// Chat. It's built on a twiligo.Client, so both APIs share credentials, retries, logging, pagination
+// and errors: failed requests return a *twiligo.APIError that matches twiligo.ErrNotFound and the
+// other sentinels, and list methods accept twiligo.PageSize and twiligo.Limit.
+//
+//	chat := twiligo.New(accountSID, authToken)
+//	client := conversations.New(chat, conversations.WithServiceSID(serviceSID))
+package conversations
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// DefaultBaseURL is the Conversations API a Client talks to unless WithBaseURL is given.
+const DefaultBaseURL = "https://conversations.twilio.com/v1"
+
+// Client provides the methods for interacting with the Twilio Conversations API.
+type Client struct {
+	api        *twiligo.Client
+	baseURL    string
+	serviceSID string
+}
+
+// Option configures optional behavior of a Client.
+type Option func(*Client)
+
+// New creates a Client that sends its requests through api. Without options, it talks to
+// DefaultBaseURL and manages the account's default Conversation Service.
+func New(api *twiligo.Client, opts ...Option) *Client {
+	client := &Client{
+		api:     api,
+		baseURL: DefaultBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// WithBaseURL points the Client at another Conversations API, such as a proxy or a fake.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithServiceSID scopes the Client's Conversations and Users to a Conversation Service instead of
+// the account's default one.
+func WithServiceSID(serviceSID string) Option {
+	return func(c *Client) {
+		c.serviceSID = serviceSID
+	}
+}
+
+// ForService returns a Client scoped to another Conversation Service in the same account.
+func (c *Client) ForService(serviceSID string) *Client {
+	scoped := *c
+	scoped.serviceSID = serviceSID
+
+	return &scoped
+}
+
+// ServiceSID returns the SID of the Conversation Service the Client is scoped to, which is empty
+// for the account's default one.
+func (c *Client) ServiceSID() string {
+	return c.serviceSID
+}
+
+// rootURL builds the URL of a resource relative to the base URL from its path segments.
+func (c *Client) rootURL(segments ...string) (string, error) {
+	path, err := rest.Path(segments...)
+
+	if err != nil {
+		return "", err
+	}
+
+	return c.baseURL + "/" + path, nil
+}
+
+// resourceURL builds the URL of a resource in the Client's Conversation Service from its path
+// segments.
+func (c *Client) resourceURL(segments ...string) (string, error) {
+	if c.serviceSID == "" {
+		return c.rootURL(segments...)
+	}
+
+	if err := rest.ValidateSID(rest.PrefixService, c.serviceSID); err != nil {
+		return "", err
+	}
+
+	return c.rootURL(append([]string{"Services", c.serviceSID}, segments...)...)
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	return c.api.Do(ctx, "GET", url, nil)
+}
+
+func (c *Client) post(ctx context.Context, url string, form url.Values) ([]byte, error) {
+	return c.api.Do(ctx, "POST", url, form)
+}
+
+func (c *Client) delete(ctx context.Context, url string) error {
+	data, err := c.api.Do(ctx, "DELETE", url, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("Received data in body of DELETE: %s", string(data))
+	}
+
+	return nil
+}
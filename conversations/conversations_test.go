@@ -0,0 +1,77 @@
+package conversations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/conversations"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestConversationRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+
+	client := srv.ConversationsClient(srv.AddService("conversations").SID)
+
+	created, err := client.CreateConversation(ctx, conversations.Conversation{UniqueName: "support/eu", FriendlyName: "Support"})
+	if err != nil {
+		t.Fatalf("CreateConversation: %v", err)
+	}
+
+	fetched, err := client.Conversation(ctx, "support/eu")
+	if err != nil {
+		t.Fatalf("Conversation: %v", err)
+	}
+	if fetched.SID != created.SID {
+		t.Errorf("fetched %s by unique name, want %s", fetched.SID, created.SID)
+	}
+
+	if _, err := client.AddParticipant(ctx, created.SID, conversations.Participant{Identity: "alice"}); err != nil {
+		t.Fatalf("AddParticipant: %v", err)
+	}
+
+	message, err := client.SendMessage(ctx, created.SID, conversations.Message{Author: "alice", Body: "hi"})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+
+	got, err := client.Message(ctx, created.SID, message.SID)
+	if err != nil {
+		t.Fatalf("Message: %v", err)
+	}
+	if got.Body != "hi" || got.Author != "alice" {
+		t.Errorf("Message = %+v", got)
+	}
+}
+
+func TestInvalidIDsAreRejected(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+
+	client := srv.ConversationsClient(srv.AddService("conversations").SID)
+
+	if _, err := client.Conversation(ctx, ""); !errors.Is(err, twiligo.ErrMissingID) {
+		t.Errorf("Conversation with an empty id returned %v, want ErrMissingID", err)
+	}
+
+	if err := client.DeleteUser(ctx, ""); !errors.Is(err, twiligo.ErrMissingID) {
+		t.Errorf("DeleteUser with an empty id returned %v, want ErrMissingID", err)
+	}
+
+	if _, err := client.Message(ctx, "CH0123456789abcdef0123456789abcdef", "general"); !errors.Is(err, twiligo.ErrInvalidSID) {
+		t.Errorf("Message with a malformed SID returned %v, want ErrInvalidSID", err)
+	}
+
+	if _, err := client.ForService("default").Conversations(ctx); !errors.Is(err, twiligo.ErrInvalidSID) {
+		t.Errorf("Conversations in a malformed Service returned %v, want ErrInvalidSID", err)
+	}
+
+	if _, err := client.CreateUser(ctx, conversations.User{Identity: "bob", Attributes: "[]"}); !errors.Is(err, twiligo.ErrInvalidAttributes) {
+		t.Errorf("CreateUser with array attributes returned %v, want ErrInvalidAttributes", err)
+	}
+}
@@ -0,0 +1,188 @@
+package conversations
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// Message is the structured representation of a Message within a Conversation.
+type Message struct {
+	SID             string     `json:"sid,omitempty"`
+	AccountSID      string     `json:"account_sid,omitempty"`
+	ChatServiceSID  string     `json:"chat_service_sid,omitempty"`
+	ConversationSID string     `json:"conversation_sid,omitempty"`
+	Index           int        `json:"index,omitempty"`
+	Author          string     `json:"author,omitempty"`
+	Body            string     `json:"body,omitempty"`
+	Media           []Media    `json:"media,omitempty"`
+	Attributes      string     `json:"attributes,omitempty"` // A JSON object encoded as a string.
+	ParticipantSID  string     `json:"participant_sid,omitempty"`
+	DateCreated     *time.Time `json:"date_created,omitempty"`
+	DateUpdated     *time.Time `json:"date_updated,omitempty"`
+	URL             string     `json:"url,omitempty"`
+}
+
+// Media is the structured representation of a file attached to a Message.
+type Media struct {
+	SID         string `json:"sid,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+}
+
+// MessageUpdate holds the fields to change on a Message. Nil fields are left as they are.
+type MessageUpdate struct {
+	Author      *string
+	Body        *string
+	Attributes  *string
+	DateCreated *time.Time
+	DateUpdated *time.Time
+}
+
+// Message retrieves a single Message of a Conversation.
+func (c *Client) Message(ctx context.Context, conversationID, messageSID string) (Message, error) {
+	var message Message
+
+	if err := rest.ValidateSID(rest.PrefixMessage, messageSID); err != nil {
+		return message, err
+	}
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Messages", messageSID)
+
+	if err != nil {
+		return message, err
+	}
+
+	data, err := c.get(ctx, resourceURL)
+
+	if err != nil {
+		return message, err
+	}
+
+	if err := json.Unmarshal(data, &message); err != nil {
+		return message, err
+	}
+
+	return message, nil
+}
+
+// Messages retrieves every Message of a Conversation, oldest first, following pagination.
+func (c *Client) Messages(ctx context.Context, conversationID string, opts ...twiligo.ListOption) ([]Message, error) {
+	listURL, err := c.resourceURL("Conversations", conversationID, "Messages")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return twiligo.List[Message](ctx, c.api, listURL, opts...)
+}
+
+// IterMessages lazily iterates over the Messages of a Conversation, oldest first, fetching pages as
+// needed.
+func (c *Client) IterMessages(ctx context.Context, conversationID string, opts ...twiligo.ListOption) iter.Seq2[Message, error] {
+	listURL, err := c.resourceURL("Conversations", conversationID, "Messages")
+
+	if err != nil {
+		return rest.Failed[Message](err)
+	}
+
+	return twiligo.Iterate[Message](ctx, c.api, listURL, opts...)
+}
+
+// SendMessage adds a Message to a Conversation. Its Author, DateCreated and DateUpdated are sent
+// when set, so imported history keeps its original authors and timestamps. The first Media's SID is
+// attached when given.
+func (c *Client) SendMessage(ctx context.Context, conversationID string, message Message) (Message, error) {
+	var sentMessage Message
+
+	if err := rest.ValidateAttributes(message.Attributes); err != nil {
+		return sentMessage, err
+	}
+
+	form := url.Values{}
+	rest.AddString(form, "Author", message.Author)
+	rest.AddString(form, "Body", message.Body)
+	rest.AddString(form, "Attributes", message.Attributes)
+	if len(message.Media) > 0 {
+		rest.AddString(form, "MediaSid", message.Media[0].SID)
+	}
+	rest.AddTime(form, "DateCreated", message.DateCreated)
+	rest.AddTime(form, "DateUpdated", message.DateUpdated)
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Messages")
+
+	if err != nil {
+		return sentMessage, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return sentMessage, err
+	}
+
+	if err := json.Unmarshal(data, &sentMessage); err != nil {
+		return sentMessage, err
+	}
+
+	return sentMessage, nil
+}
+
+// UpdateMessage changes a Message of a Conversation. Only the non-nil fields of update are sent.
+func (c *Client) UpdateMessage(ctx context.Context, conversationID, messageSID string, update MessageUpdate) (Message, error) {
+	var updatedMessage Message
+
+	if err := rest.ValidateSID(rest.PrefixMessage, messageSID); err != nil {
+		return updatedMessage, err
+	}
+
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedMessage, err
+	}
+
+	form := url.Values{}
+	rest.SetString(form, "Author", update.Author)
+	rest.SetString(form, "Body", update.Body)
+	rest.SetString(form, "Attributes", update.Attributes)
+	rest.AddTime(form, "DateCreated", update.DateCreated)
+	rest.AddTime(form, "DateUpdated", update.DateUpdated)
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Messages", messageSID)
+
+	if err != nil {
+		return updatedMessage, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return updatedMessage, err
+	}
+
+	if err := json.Unmarshal(data, &updatedMessage); err != nil {
+		return updatedMessage, err
+	}
+
+	return updatedMessage, nil
+}
+
+// DeleteMessage removes a Message from a Conversation.
+func (c *Client) DeleteMessage(ctx context.Context, conversationID, messageSID string) error {
+	if err := rest.ValidateSID(rest.PrefixMessage, messageSID); err != nil {
+		return err
+	}
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Messages", messageSID)
+
+	if err != nil {
+		return err
+	}
+
+	return c.delete(ctx, resourceURL)
+}
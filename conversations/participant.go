@@ -0,0 +1,189 @@
+package conversations
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// Participant is the structured representation of a member of a Conversation, the counterpart of a
+// Chat Member. Chat Participants have an Identity, while SMS and WhatsApp Participants have a
+// MessagingBinding instead.
+type Participant struct {
+	SID                  string            `json:"sid,omitempty"`
+	AccountSID           string            `json:"account_sid,omitempty"`
+	ChatServiceSID       string            `json:"chat_service_sid,omitempty"`
+	ConversationSID      string            `json:"conversation_sid,omitempty"`
+	Identity             string            `json:"identity,omitempty"`
+	Attributes           string            `json:"attributes,omitempty"` // A JSON object encoded as a string.
+	RoleSID              string            `json:"role_sid,omitempty"`
+	MessagingBinding     *MessagingBinding `json:"messaging_binding,omitempty"`
+	LastReadMessageIndex *int              `json:"last_read_message_index,omitempty"`
+	LastReadTimestamp    string            `json:"last_read_timestamp,omitempty"`
+	DateCreated          *time.Time        `json:"date_created,omitempty"`
+	DateUpdated          *time.Time        `json:"date_updated,omitempty"`
+	URL                  string            `json:"url,omitempty"`
+}
+
+// MessagingBinding is the structured representation of the phone number a non-chat Participant is
+// reached at.
+type MessagingBinding struct {
+	Type         string `json:"type,omitempty"`
+	Address      string `json:"address,omitempty"`
+	ProxyAddress string `json:"proxy_address,omitempty"`
+}
+
+// ParticipantUpdate holds the fields to change on a Participant. Nil fields are left as they are.
+type ParticipantUpdate struct {
+	Attributes           *string
+	RoleSID              *string
+	LastReadMessageIndex *int
+	DateCreated          *time.Time
+	DateUpdated          *time.Time
+}
+
+// Participant retrieves a single Participant of a Conversation.
+func (c *Client) Participant(ctx context.Context, conversationID, participantSID string) (Participant, error) {
+	var participant Participant
+
+	if err := rest.ValidateSID(rest.PrefixParticipant, participantSID); err != nil {
+		return participant, err
+	}
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Participants", participantSID)
+
+	if err != nil {
+		return participant, err
+	}
+
+	data, err := c.get(ctx, resourceURL)
+
+	if err != nil {
+		return participant, err
+	}
+
+	if err := json.Unmarshal(data, &participant); err != nil {
+		return participant, err
+	}
+
+	return participant, nil
+}
+
+// Participants retrieves every Participant of a Conversation, following pagination.
+func (c *Client) Participants(ctx context.Context, conversationID string, opts ...twiligo.ListOption) ([]Participant, error) {
+	listURL, err := c.resourceURL("Conversations", conversationID, "Participants")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return twiligo.List[Participant](ctx, c.api, listURL, opts...)
+}
+
+// IterParticipants lazily iterates over the Participants of a Conversation, fetching pages as needed.
+func (c *Client) IterParticipants(ctx context.Context, conversationID string, opts ...twiligo.ListOption) iter.Seq2[Participant, error] {
+	listURL, err := c.resourceURL("Conversations", conversationID, "Participants")
+
+	if err != nil {
+		return rest.Failed[Participant](err)
+	}
+
+	return twiligo.Iterate[Participant](ctx, c.api, listURL, opts...)
+}
+
+// AddParticipant adds a Participant to a Conversation, either by Identity or by MessagingBinding.
+func (c *Client) AddParticipant(ctx context.Context, conversationID string, participant Participant) (Participant, error) {
+	var addedParticipant Participant
+
+	if err := rest.ValidateAttributes(participant.Attributes); err != nil {
+		return addedParticipant, err
+	}
+
+	form := url.Values{}
+	rest.AddString(form, "Identity", participant.Identity)
+	rest.AddString(form, "Attributes", participant.Attributes)
+	rest.AddString(form, "RoleSid", participant.RoleSID)
+	if binding := participant.MessagingBinding; binding != nil {
+		rest.AddString(form, "MessagingBinding.Address", binding.Address)
+		rest.AddString(form, "MessagingBinding.ProxyAddress", binding.ProxyAddress)
+	}
+	rest.AddTime(form, "DateCreated", participant.DateCreated)
+	rest.AddTime(form, "DateUpdated", participant.DateUpdated)
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Participants")
+
+	if err != nil {
+		return addedParticipant, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return addedParticipant, err
+	}
+
+	if err := json.Unmarshal(data, &addedParticipant); err != nil {
+		return addedParticipant, err
+	}
+
+	return addedParticipant, nil
+}
+
+// UpdateParticipant changes a Participant of a Conversation. Only the non-nil fields of update are
+// sent.
+func (c *Client) UpdateParticipant(ctx context.Context, conversationID, participantSID string, update ParticipantUpdate) (Participant, error) {
+	var updatedParticipant Participant
+
+	if err := rest.ValidateSID(rest.PrefixParticipant, participantSID); err != nil {
+		return updatedParticipant, err
+	}
+
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedParticipant, err
+	}
+
+	form := url.Values{}
+	rest.SetString(form, "Attributes", update.Attributes)
+	rest.SetString(form, "RoleSid", update.RoleSID)
+	rest.SetInt(form, "LastReadMessageIndex", update.LastReadMessageIndex)
+	rest.AddTime(form, "DateCreated", update.DateCreated)
+	rest.AddTime(form, "DateUpdated", update.DateUpdated)
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Participants", participantSID)
+
+	if err != nil {
+		return updatedParticipant, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return updatedParticipant, err
+	}
+
+	if err := json.Unmarshal(data, &updatedParticipant); err != nil {
+		return updatedParticipant, err
+	}
+
+	return updatedParticipant, nil
+}
+
+// RemoveParticipant removes a Participant from a Conversation.
+func (c *Client) RemoveParticipant(ctx context.Context, conversationID, participantSID string) error {
+	if err := rest.ValidateSID(rest.PrefixParticipant, participantSID); err != nil {
+		return err
+	}
+
+	resourceURL, err := c.resourceURL("Conversations", conversationID, "Participants", participantSID)
+
+	if err != nil {
+		return err
+	}
+
+	return c.delete(ctx, resourceURL)
+}
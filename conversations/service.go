@@ -0,0 +1,124 @@
+package conversations
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// Service is the structured representation of a Conversation Service, the counterpart of a Chat
+// Service. Every Conversation and User belongs to one.
+type Service struct {
+	SID          string       `json:"sid,omitempty"`
+	AccountSID   string       `json:"account_sid,omitempty"`
+	FriendlyName string       `json:"friendly_name,omitempty"`
+	DateCreated  *time.Time   `json:"date_created,omitempty"`
+	DateUpdated  *time.Time   `json:"date_updated,omitempty"`
+	URL          string       `json:"url,omitempty"`
+	Links        ServiceLinks `json:"links,omitempty"`
+}
+
+// ServiceLinks is the structured representation of the links within a Service.
+type ServiceLinks struct {
+	Conversations string `json:"conversations,omitempty"`
+	Users         string `json:"users,omitempty"`
+	Roles         string `json:"roles,omitempty"`
+	Bindings      string `json:"bindings,omitempty"`
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// Service retrieves a single Conversation Service.
+func (c *Client) Service(ctx context.Context, sid string) (Service, error) {
+	var service Service
+
+	if err := rest.ValidateSID(rest.PrefixService, sid); err != nil {
+		return service, err
+	}
+
+	resourceURL, err := c.rootURL("Services", sid)
+
+	if err != nil {
+		return service, err
+	}
+
+	data, err := c.get(ctx, resourceURL)
+
+	if err != nil {
+		return service, err
+	}
+
+	if err := json.Unmarshal(data, &service); err != nil {
+		return service, err
+	}
+
+	return service, nil
+}
+
+// Services retrieves every Conversation Service of the account, following pagination.
+func (c *Client) Services(ctx context.Context, opts ...twiligo.ListOption) ([]Service, error) {
+	listURL, err := c.rootURL("Services")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return twiligo.List[Service](ctx, c.api, listURL, opts...)
+}
+
+// IterServices lazily iterates over the Conversation Services of the account, fetching pages as
+// needed.
+func (c *Client) IterServices(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[Service, error] {
+	listURL, err := c.rootURL("Services")
+
+	if err != nil {
+		return rest.Failed[Service](err)
+	}
+
+	return twiligo.Iterate[Service](ctx, c.api, listURL, opts...)
+}
+
+// CreateService creates a new Conversation Service.
+func (c *Client) CreateService(ctx context.Context, service Service) (Service, error) {
+	var createdService Service
+
+	form := url.Values{}
+	form.Add("FriendlyName", service.FriendlyName)
+
+	resourceURL, err := c.rootURL("Services")
+
+	if err != nil {
+		return createdService, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return createdService, err
+	}
+
+	if err := json.Unmarshal(data, &createdService); err != nil {
+		return createdService, err
+	}
+
+	return createdService, nil
+}
+
+// DeleteService deletes a Conversation Service along with everything within it.
+func (c *Client) DeleteService(ctx context.Context, sid string) error {
+	if err := rest.ValidateSID(rest.PrefixService, sid); err != nil {
+		return err
+	}
+
+	resourceURL, err := c.rootURL("Services", sid)
+
+	if err != nil {
+		return err
+	}
+
+	return c.delete(ctx, resourceURL)
+}
@@ -0,0 +1,156 @@
+package conversations
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// User is the structured representation of a Conversations User.
+type User struct {
+	SID            string     `json:"sid,omitempty"`
+	AccountSID     string     `json:"account_sid,omitempty"`
+	ChatServiceSID string     `json:"chat_service_sid,omitempty"`
+	RoleSID        string     `json:"role_sid,omitempty"`
+	Identity       string     `json:"identity,omitempty"`
+	FriendlyName   string     `json:"friendly_name,omitempty"`
+	Attributes     string     `json:"attributes,omitempty"` // A JSON object encoded as a string.
+	IsOnline       *bool      `json:"is_online,omitempty"`
+	IsNotifiable   *bool      `json:"is_notifiable,omitempty"`
+	DateCreated    *time.Time `json:"date_created,omitempty"`
+	DateUpdated    *time.Time `json:"date_updated,omitempty"`
+	URL            string     `json:"url,omitempty"`
+}
+
+// UserUpdate holds the fields to change on a User. Nil fields are left as they are.
+type UserUpdate struct {
+	FriendlyName *string
+	Attributes   *string
+	RoleSID      *string
+}
+
+// User retrieves a single User by SID or identity.
+func (c *Client) User(ctx context.Context, identity string) (User, error) {
+	var user User
+
+	resourceURL, err := c.resourceURL("Users", identity)
+
+	if err != nil {
+		return user, err
+	}
+
+	data, err := c.get(ctx, resourceURL)
+
+	if err != nil {
+		return user, err
+	}
+
+	if err := json.Unmarshal(data, &user); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+// Users retrieves every User, following pagination.
+func (c *Client) Users(ctx context.Context, opts ...twiligo.ListOption) ([]User, error) {
+	listURL, err := c.resourceURL("Users")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return twiligo.List[User](ctx, c.api, listURL, opts...)
+}
+
+// IterUsers lazily iterates over the Users, fetching pages as needed.
+func (c *Client) IterUsers(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[User, error] {
+	listURL, err := c.resourceURL("Users")
+
+	if err != nil {
+		return rest.Failed[User](err)
+	}
+
+	return twiligo.Iterate[User](ctx, c.api, listURL, opts...)
+}
+
+// CreateUser creates a new User.
+func (c *Client) CreateUser(ctx context.Context, user User) (User, error) {
+	var createdUser User
+
+	if err := rest.ValidateAttributes(user.Attributes); err != nil {
+		return createdUser, err
+	}
+
+	form := url.Values{}
+	form.Add("Identity", user.Identity)
+	rest.AddString(form, "FriendlyName", user.FriendlyName)
+	rest.AddString(form, "Attributes", user.Attributes)
+	rest.AddString(form, "RoleSid", user.RoleSID)
+
+	resourceURL, err := c.resourceURL("Users")
+
+	if err != nil {
+		return createdUser, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return createdUser, err
+	}
+
+	if err := json.Unmarshal(data, &createdUser); err != nil {
+		return createdUser, err
+	}
+
+	return createdUser, nil
+}
+
+// UpdateUser changes a User, given by SID or identity. Only the non-nil fields of update are sent.
+func (c *Client) UpdateUser(ctx context.Context, identity string, update UserUpdate) (User, error) {
+	var updatedUser User
+
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedUser, err
+	}
+
+	form := url.Values{}
+	rest.SetString(form, "FriendlyName", update.FriendlyName)
+	rest.SetString(form, "Attributes", update.Attributes)
+	rest.SetString(form, "RoleSid", update.RoleSID)
+
+	resourceURL, err := c.resourceURL("Users", identity)
+
+	if err != nil {
+		return updatedUser, err
+	}
+
+	data, err := c.post(ctx, resourceURL, form)
+
+	if err != nil {
+		return updatedUser, err
+	}
+
+	if err := json.Unmarshal(data, &updatedUser); err != nil {
+		return updatedUser, err
+	}
+
+	return updatedUser, nil
+}
+
+// DeleteUser deletes a User, given by SID or identity.
+func (c *Client) DeleteUser(ctx context.Context, identity string) error {
+	resourceURL, err := c.resourceURL("Users", identity)
+
+	if err != nil {
+		return err
+	}
+
+	return c.delete(ctx, resourceURL)
+}
@@ -0,0 +1,195 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// Credential is the structured representation of the push notification credentials of an account,
+// e.g. an APN certificate or an FCM server key. Credentials belong to the account rather than a
+// Service and are only exposed by v2.
+type Credential struct {
+	SID          string      `json:"sid,omitempty"`
+	AccountSID   string      `json:"account_sid,omitempty"`
+	FriendlyName string      `json:"friendly_name,omitempty"`
+	Type         BindingType `json:"type,omitempty"`
+	Sandbox      string      `json:"sandbox,omitempty"` // "true" for APN sandbox credentials.
+	DateCreated  *time.Time  `json:"date_created,omitempty"`
+	DateUpdated  *time.Time  `json:"date_updated,omitempty"`
+	URL          string      `json:"url,omitempty"`
+}
+
+// CredentialSecrets holds the secret parts of a Credential. Twilio never returns them, so they're
+// only sent when creating or updating one. Which fields apply depends on the Credential's type.
+type CredentialSecrets struct {
+	Certificate *string // APN certificate in PEM format.
+	PrivateKey  *string // APN private key in PEM format.
+	Sandbox     *bool   // Whether an APN credential targets the sandbox.
+	APIKey      *string // GCM server key.
+	Secret      *string // FCM server key.
+}
+
+// CredentialUpdate holds the fields to change on a Credential. Nil fields are left as they are.
+type CredentialUpdate struct {
+	FriendlyName *string
+	CredentialSecrets
+}
+
+func addCredentialSecrets(form url.Values, secrets CredentialSecrets) {
+	rest.SetString(form, "Certificate", secrets.Certificate)
+	rest.SetString(form, "PrivateKey", secrets.PrivateKey)
+	rest.SetBool(form, "Sandbox", secrets.Sandbox)
+	rest.SetString(form, "ApiKey", secrets.APIKey)
+	rest.SetString(form, "Secret", secrets.Secret)
+}
+
+// Credential retrieves a single Credential of the account.
+func (c *Client) Credential(ctx context.Context, sid string) (Credential, error) {
+	var credential Credential
+
+	if err := c.requireV2("credentials"); err != nil {
+		return credential, err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixCredential, sid); err != nil {
+		return credential, err
+	}
+
+	path, err := rest.Path("Credentials", sid)
+
+	if err != nil {
+		return credential, err
+	}
+
+	data, err := c.get(ctx, c.rootURL(path), nil)
+
+	if err != nil {
+		return credential, err
+	}
+
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return credential, err
+	}
+
+	return credential, nil
+}
+
+// Credentials retrieves every Credential of the account, following pagination.
+func (c *Client) Credentials(ctx context.Context, opts ...ListOption) ([]Credential, error) {
+	if err := c.requireV2("credentials"); err != nil {
+		return nil, err
+	}
+
+	return listAll[Credential](ctx, c, c.rootURL("Credentials"), opts)
+}
+
+// IterCredentials lazily iterates over the Credentials of the account, fetching pages as needed.
+func (c *Client) IterCredentials(ctx context.Context, opts ...ListOption) iter.Seq2[Credential, error] {
+	if err := c.requireV2("credentials"); err != nil {
+		return rest.Failed[Credential](err)
+	}
+
+	return iterate[Credential](ctx, c, c.rootURL("Credentials"), opts)
+}
+
+// CreateCredential creates a new Credential from the public fields of credential and the given
+// secrets.
+func (c *Client) CreateCredential(ctx context.Context, credential Credential, secrets CredentialSecrets) (Credential, error) {
+	var createdCredential Credential
+
+	if err := c.requireV2("credentials"); err != nil {
+		return createdCredential, err
+	}
+
+	form := url.Values{}
+	form.Add("Type", string(credential.Type))
+	if credential.FriendlyName != "" {
+		form.Add("FriendlyName", credential.FriendlyName)
+	}
+	addCredentialSecrets(form, secrets)
+	payload := []byte(form.Encode())
+
+	data, err := c.post(ctx, c.rootURL("Credentials"), payload, getFormHeader())
+
+	if err != nil {
+		return createdCredential, err
+	}
+
+	if err := json.Unmarshal(data, &createdCredential); err != nil {
+		return createdCredential, err
+	}
+
+	return createdCredential, nil
+}
+
+// UpdateCredential changes a Credential, e.g. to rotate an expiring certificate. Only the non-nil
+// fields of update are sent.
+func (c *Client) UpdateCredential(ctx context.Context, sid string, update CredentialUpdate) (Credential, error) {
+	var updatedCredential Credential
+
+	if err := c.requireV2("credentials"); err != nil {
+		return updatedCredential, err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixCredential, sid); err != nil {
+		return updatedCredential, err
+	}
+
+	form := url.Values{}
+	rest.SetString(form, "FriendlyName", update.FriendlyName)
+	addCredentialSecrets(form, update.CredentialSecrets)
+	payload := []byte(form.Encode())
+
+	path, err := rest.Path("Credentials", sid)
+
+	if err != nil {
+		return updatedCredential, err
+	}
+
+	data, err := c.post(ctx, c.rootURL(path), payload, getFormHeader())
+
+	if err != nil {
+		return updatedCredential, err
+	}
+
+	if err := json.Unmarshal(data, &updatedCredential); err != nil {
+		return updatedCredential, err
+	}
+
+	return updatedCredential, nil
+}
+
+// DeleteCredential removes a Credential from the account.
+func (c *Client) DeleteCredential(ctx context.Context, sid string) error {
+	if err := c.requireV2("credentials"); err != nil {
+		return err
+	}
+
+	if err := rest.ValidateSID(rest.PrefixCredential, sid); err != nil {
+		return err
+	}
+
+	path, err := rest.Path("Credentials", sid)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.delete(ctx, c.rootURL(path))
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("Received data in body of DELETE: %s", string(data))
+	}
+
+	return nil
+}
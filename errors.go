@@ -0,0 +1,79 @@
+package twiligo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// Sentinel errors that an *APIError can be matched against with errors.Is.
+var (
+	ErrNotFound     = errors.New("twiligo: resource not found")
+	ErrConflict     = errors.New("twiligo: resource conflict")
+	ErrUnauthorized = errors.New("twiligo: unauthorized")
+	ErrRateLimited  = errors.New("twiligo: rate limited")
+)
+
+// ErrInvalidSID is returned before making a request when a parameter that must be a SID isn't one.
+var ErrInvalidSID = rest.ErrInvalidSID
+
+// ErrMissingID is returned before making a request when a SID, unique name or identity is empty,
+// which would otherwise address the list endpoint instead of a single resource.
+var ErrMissingID = rest.ErrMissingID
+
+// APIError is the structured representation of an error response from Twilio.
+type APIError struct {
+	StatusCode int    `json:"-"` // The HTTP status code of the response.
+	Code       int    `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+	MoreInfo   string `json:"more_info,omitempty"`
+	Status     int    `json:"status,omitempty"`
+
+	// RetryAfter is the delay requested by Twilio through the Retry-After header, if any.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// newAPIError builds an APIError from a non-2xx response. Bodies that aren't Twilio shaped JSON are
+// kept as the error message so nothing is lost.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = string(body)
+	}
+
+	if apiErr.Status == 0 {
+		apiErr.Status = statusCode
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("twilio: %d %s (code %d): %s", e.StatusCode, http.StatusText(e.StatusCode), e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("twilio: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is reports whether the APIError matches one of the package's sentinel errors based on its
+// HTTP status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
@@ -0,0 +1,151 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestAPIErrorSentinels(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+	service := srv.AddService("errors")
+	client := srv.Client(service.SID)
+
+	if _, err := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public")); err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	_, duplicate := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public"))
+	_, missing := client.Channel(ctx, "missing")
+	_, unauthorized := twiligo.NewClient(srv.URL, srv.AccountSID, service.SID, "wrong").Channels(ctx)
+
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		status   int
+	}{
+		{"duplicate unique name", duplicate, twiligo.ErrConflict, http.StatusConflict},
+		{"unknown channel", missing, twiligo.ErrNotFound, http.StatusNotFound},
+		{"wrong auth token", unauthorized, twiligo.ErrUnauthorized, http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !errors.Is(test.err, test.sentinel) {
+				t.Errorf("%v doesn't match %v", test.err, test.sentinel)
+			}
+
+			var apiErr *twiligo.APIError
+			if !errors.As(test.err, &apiErr) {
+				t.Fatalf("%v isn't an *APIError", test.err)
+			}
+
+			if apiErr.StatusCode != test.status || apiErr.Message == "" {
+				t.Errorf("got status %d and message %q, want %d with a message", apiErr.StatusCode, apiErr.Message, test.status)
+			}
+		})
+	}
+
+	var apiErr *twiligo.APIError
+	if errors.As(duplicate, &apiErr) && apiErr.Code != 50307 {
+		t.Errorf("duplicate unique name has code %d, want 50307", apiErr.Code)
+	}
+}
+
+// errorClient returns a Client for a server that answers every request with the given status and
+// body.
+func errorClient(t *testing.T, status int, body string) *twiligo.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return twiligo.NewClient(srv.URL, "AC123", "IS0123456789abcdef0123456789abcdef", "token")
+}
+
+func TestAPIErrorFromEveryVerb(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		sentinel error
+		code     int
+		message  string
+	}{
+		{"not found", http.StatusNotFound, `{"code": 20404, "message": "The requested resource was not found", "status": 404}`, twiligo.ErrNotFound, 20404, "The requested resource was not found"},
+		{"conflict", http.StatusConflict, `{"code": 50307, "message": "Channel with provided unique name already exists", "status": 409}`, twiligo.ErrConflict, 50307, "Channel with provided unique name already exists"},
+		{"unauthorized", http.StatusUnauthorized, `{"code": 20003, "message": "Authenticate", "status": 401}`, twiligo.ErrUnauthorized, 20003, "Authenticate"},
+		{"rate limited", http.StatusTooManyRequests, `{"code": 20429, "message": "Too Many Requests", "status": 429}`, twiligo.ErrRateLimited, 20429, "Too Many Requests"},
+		{"not twilio json", http.StatusBadGateway, "<html>Bad Gateway</html>", nil, 0, "<html>Bad Gateway</html>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := errorClient(t, test.status, test.body)
+
+			_, getErr := client.Channel(ctx, "CH123")
+			_, postErr := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public"))
+			deleteErr := client.DeleteChannel(ctx, "CH123")
+
+			for verb, err := range map[string]error{"GET": getErr, "POST": postErr, "DELETE": deleteErr} {
+				var apiErr *twiligo.APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("%s returned %v, want an *APIError", verb, err)
+				}
+
+				if apiErr.StatusCode != test.status || apiErr.Status != test.status || apiErr.Code != test.code || apiErr.Message != test.message {
+					t.Errorf("%s returned %+v", verb, apiErr)
+				}
+
+				if test.sentinel != nil && !errors.Is(err, test.sentinel) {
+					t.Errorf("%s returned %v, want it to match %v", verb, err, test.sentinel)
+				}
+
+				for _, other := range []error{twiligo.ErrNotFound, twiligo.ErrConflict, twiligo.ErrUnauthorized, twiligo.ErrRateLimited} {
+					if other != test.sentinel && errors.Is(err, other) {
+						t.Errorf("%s returned %v, which matches %v", verb, err, other)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorRateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("slow down"))
+	}))
+	defer srv.Close()
+
+	client := twiligo.NewClient(srv.URL, "AC123", "IS0123456789abcdef0123456789abcdef", "token")
+	_, err := client.Channels(context.Background())
+
+	var apiErr *twiligo.APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, twiligo.ErrRateLimited) {
+		t.Fatalf("Channels returned %v, want a rate limited *APIError", err)
+	}
+
+	// A body that isn't Twilio's JSON is kept as the message.
+	if apiErr.RetryAfter != 7*time.Second || apiErr.Message != "slow down" || apiErr.Status != http.StatusTooManyRequests {
+		t.Errorf("got %+v", apiErr)
+	}
+
+	if errors.Is(err, twiligo.ErrNotFound) {
+		t.Error("a 429 matches ErrNotFound")
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/eriktate/twiligo
+
+go 1.23
@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidAttributes is returned when attributes are not a JSON object, which Twilio requires
+// even though it stores them as a string.
+var ErrInvalidAttributes = errors.New("twiligo: attributes must be a JSON object")
+
+// ValidateAttributes checks that attributes are either empty or a JSON object before they're sent
+// to Twilio.
+func ValidateAttributes(attrs string) error {
+	if attrs == "" {
+		return nil
+	}
+
+	trimmed := bytes.TrimSpace([]byte(attrs))
+	if !json.Valid(trimmed) || len(trimmed) == 0 || trimmed[0] != '{' {
+		return fmt.Errorf("%w: %q", ErrInvalidAttributes, attrs)
+	}
+
+	return nil
+}
+
+// ValidateAttributesUpdate is ValidateAttributes for the optional attributes of update params.
+func ValidateAttributesUpdate(attrs *string) error {
+	if attrs == nil {
+		return nil
+	}
+
+	return ValidateAttributes(*attrs)
+}
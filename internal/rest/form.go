@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SetString adds the parameter to the form only if it was set, so Twilio leaves it unchanged
+// otherwise. A pointer to an empty string explicitly clears the value.
+func SetString(form url.Values, key string, value *string) {
+	if value != nil {
+		form.Set(key, *value)
+	}
+}
+
+// SetBool is SetString for bools.
+func SetBool(form url.Values, key string, value *bool) {
+	if value != nil {
+		form.Set(key, strconv.FormatBool(*value))
+	}
+}
+
+// SetInt is SetString for ints.
+func SetInt(form url.Values, key string, value *int) {
+	if value != nil {
+		form.Set(key, strconv.Itoa(*value))
+	}
+}
+
+// SetList replaces a list parameter only if the slice is non-nil. An empty slice is sent as a single
+// empty value, which is how Twilio clears a list.
+func SetList(form url.Values, key string, values []string) {
+	switch {
+	case values == nil:
+	case len(values) == 0:
+		form.Set(key, "")
+	default:
+		form[key] = values
+	}
+}
+
+// AddString adds the parameter to the form only if it isn't empty.
+func AddString(form url.Values, key, value string) {
+	if value != "" {
+		form.Set(key, value)
+	}
+}
+
+// AddTime adds a timestamp parameter in the ISO 8601 format Twilio expects, if it's set. Setting
+// DateCreated and DateUpdated is how imported history keeps its original timestamps.
+func AddTime(form url.Values, key string, value *time.Time) {
+	if value != nil && !value.IsZero() {
+		form.Set(key, value.UTC().Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,77 @@
+// Package rest holds the request plumbing shared by the Chat client in twiligo and the
+// Conversations client: resource paths, SID and attribute validation, and form encoding.
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// Errors returned before a request is made, re-exported by twiligo.
+var (
+	ErrInvalidSID = errors.New("twiligo: invalid SID")
+	ErrMissingID  = errors.New("twiligo: missing id")
+)
+
+// The prefixes Twilio gives the SIDs of each kind of resource.
+const (
+	PrefixService     = "IS"
+	PrefixMessage     = "IM"
+	PrefixRole        = "RL"
+	PrefixWebhook     = "WH"
+	PrefixBinding     = "BS"
+	PrefixCredential  = "CR"
+	PrefixParticipant = "MB"
+)
+
+// Path joins path segments, escaping each one so identities and unique names containing reserved
+// characters such as "/", "?", "#" or spaces address the intended resource. Empty segments fail
+// with ErrMissingID, since they would address the list endpoint instead of a single resource.
+func Path(segments ...string) (string, error) {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		if segment == "" {
+			return "", fmt.Errorf("%w in %q", ErrMissingID, strings.Join(segments, "/"))
+		}
+		escaped[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(escaped, "/"), nil
+}
+
+// IsSID reports whether id is a SID with the given prefix: two letters followed by 32 hex digits.
+func IsSID(prefix, id string) bool {
+	if len(id) != 34 || !strings.HasPrefix(id, prefix) {
+		return false
+	}
+
+	for _, r := range id[2:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateSID fails with ErrInvalidSID when sid isn't a SID with the given prefix. It's used for
+// parameters that Twilio only accepts as SIDs, where a malformed value would otherwise reach
+// Twilio as a confusing 404.
+func ValidateSID(prefix, sid string) error {
+	if !IsSID(prefix, sid) {
+		return fmt.Errorf("%w: %q is not a valid SID with prefix %s", ErrInvalidSID, sid, prefix)
+	}
+
+	return nil
+}
+
+// Failed returns an iterator that only yields err, for iterators that can't be started.
+func Failed[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
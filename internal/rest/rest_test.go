@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestPath(t *testing.T) {
+	path, err := Path("Users", "alice/admin?x=1#top", "Channels")
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+	if want := "Users/alice%2Fadmin%3Fx=1%23top/Channels"; path != want {
+		t.Errorf("Path = %q, want %q", path, want)
+	}
+
+	if _, err := Path("Channels", "", "Messages"); !errors.Is(err, ErrMissingID) {
+		t.Errorf("Path with an empty segment returned %v, want ErrMissingID", err)
+	}
+}
+
+func TestValidateSID(t *testing.T) {
+	valid := "IS0123456789abcdef0123456789ABCDEF"
+	if err := ValidateSID(PrefixService, valid); err != nil {
+		t.Errorf("ValidateSID(%q): %v", valid, err)
+	}
+
+	for _, sid := range []string{"", "general", "CH0123456789abcdef0123456789abcdef", "IS0123456789abcdef0123456789abcdeg", valid + "0"} {
+		if err := ValidateSID(PrefixService, sid); !errors.Is(err, ErrInvalidSID) {
+			t.Errorf("ValidateSID(%q) returned %v, want ErrInvalidSID", sid, err)
+		}
+	}
+}
+
+func TestValidateAttributes(t *testing.T) {
+	for _, attrs := range []string{"", "{}", ` {"a": [1, 2]} `} {
+		if err := ValidateAttributes(attrs); err != nil {
+			t.Errorf("ValidateAttributes(%q): %v", attrs, err)
+		}
+	}
+
+	for _, attrs := range []string{"[]", `"a"`, "1", "{", "null"} {
+		if err := ValidateAttributes(attrs); !errors.Is(err, ErrInvalidAttributes) {
+			t.Errorf("ValidateAttributes(%q) returned %v, want ErrInvalidAttributes", attrs, err)
+		}
+	}
+}
+
+func TestSetList(t *testing.T) {
+	form := url.Values{}
+	SetList(form, "Nil", nil)
+	SetList(form, "Empty", []string{})
+	SetList(form, "Full", []string{"a", "b"})
+
+	if form.Has("Nil") {
+		t.Error("a nil list was sent")
+	}
+	if got := form["Empty"]; len(got) != 1 || got[0] != "" {
+		t.Errorf("an empty list was sent as %q, want a single empty value", got)
+	}
+	if got := form["Full"]; len(got) != 2 {
+		t.Errorf("a full list was sent as %q", got)
+	}
+}
+
+func TestAddTime(t *testing.T) {
+	form := url.Values{}
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	AddTime(form, "DateCreated", &created)
+	AddTime(form, "DateUpdated", &time.Time{})
+
+	if got := form.Get("DateCreated"); got != "2020-01-02T02:04:05Z" {
+		t.Errorf("DateCreated = %q, want it in UTC", got)
+	}
+	if form.Has("DateUpdated") {
+		t.Error("a zero time was sent")
+	}
+}
@@ -0,0 +1,136 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Logger is the leveled logger a Client reports its requests to. A *slog.Logger satisfies it.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+// nopLogger is the default Logger, a Client is silent unless given one.
+type nopLogger struct{}
+
+func (nopLogger) DebugContext(context.Context, string, ...any) {}
+func (nopLogger) InfoContext(context.Context, string, ...any)  {}
+func (nopLogger) WarnContext(context.Context, string, ...any)  {}
+func (nopLogger) ErrorContext(context.Context, string, ...any) {}
+
+// WithLogger sets the Logger a Client reports requests, responses and retries to. Requests and
+// responses are logged at debug level and retries at warn level.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		if logger == nil {
+			logger = nopLogger{}
+		}
+
+		c.logger = logger
+	}
+}
+
+// WithPayloadLogging includes request and response payloads in debug logs. Message bodies,
+// attributes and credential secrets are redacted unless WithUnredactedLogging is also given.
+func WithPayloadLogging() Option {
+	return func(c *Client) {
+		c.logPayloads = true
+	}
+}
+
+// WithUnredactedLogging disables the redaction of message bodies, attributes and credential secrets
+// in logged payloads.
+// Only use this when debugging against non-production data.
+func WithUnredactedLogging() Option {
+	return func(c *Client) {
+		c.unredacted = true
+	}
+}
+
+const redacted = "[REDACTED]"
+
+// sensitiveFields are redacted from logged payloads, matched case-insensitively so they cover both
+// form parameters (Body) and JSON properties (body).
+var sensitiveFields = map[string]bool{
+	"body":        true,
+	"attributes":  true,
+	"certificate": true,
+	"privatekey":  true,
+	"apikey":      true,
+	"secret":      true,
+}
+
+// redactHeaders returns a copy of the headers with credentials removed.
+func redactHeaders(headers http.Header) http.Header {
+	clean := headers.Clone()
+	if clean.Get("Authorization") != "" {
+		clean.Set("Authorization", redacted)
+	}
+
+	return clean
+}
+
+// redactForm prepares a form encoded payload for logging.
+func (c *Client) redactForm(payload []byte) string {
+	if c.unredacted || len(payload) == 0 {
+		return string(payload)
+	}
+
+	form, err := url.ParseQuery(string(payload))
+	if err != nil {
+		return redacted
+	}
+
+	for key := range form {
+		if sensitiveFields[strings.ToLower(key)] {
+			form.Set(key, redacted)
+		}
+	}
+
+	return form.Encode()
+}
+
+// redactJSON prepares a JSON payload for logging.
+func (c *Client) redactJSON(payload []byte) string {
+	if c.unredacted || len(payload) == 0 {
+		return string(payload)
+	}
+
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return redacted
+	}
+
+	data, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redacted
+	}
+
+	return string(data)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,43 @@
+package twiligo_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestPayloadLoggingRedactsSecrets(t *testing.T) {
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := srv.Client("", twiligo.WithLogger(logger), twiligo.WithPayloadLogging())
+
+	secrets := twiligo.CredentialSecrets{
+		Certificate: twiligo.String("certificate-secret"),
+		PrivateKey:  twiligo.String("private-key-secret"),
+		APIKey:      twiligo.String("api-key-secret"),
+		Secret:      twiligo.String("secret-secret"),
+	}
+
+	_, err := client.CreateCredential(context.Background(), twiligo.Credential{FriendlyName: "push", Type: twiligo.BindingTypeFCM}, secrets)
+	if err != nil {
+		t.Fatalf("CreateCredential: %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "push") {
+		t.Fatalf("payload wasn't logged:\n%s", logs.String())
+	}
+
+	for _, secret := range []string{"certificate-secret", "private-key-secret", "api-key-secret", "secret-secret"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("%s leaked into the log:\n%s", secret, logs.String())
+		}
+	}
+}
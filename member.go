@@ -0,0 +1,200 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// Member is the structured representation of a User's membership in a Twilio Channel.
+type Member struct {
+	SID                      string     `json:"sid,omitempty"`
+	AccountSID               string     `json:"account_sid,omitempty"`
+	ChannelSID               string     `json:"channel_sid,omitempty"`
+	ServiceSID               string     `json:"service_sid,omitempty"`
+	Identity                 string     `json:"identity,omitempty"`
+	RoleSID                  string     `json:"role_sid,omitempty"`
+	Attributes               string     `json:"attributes,omitempty"` // Only supported by v2.
+	LastConsumedMessageIndex *int       `json:"last_consumed_message_index,omitempty"`
+	LastConsumptionTimestamp *time.Time `json:"last_consumption_timestamp,omitempty"`
+	DateCreated              *time.Time `json:"date_created,omitempty"`
+	DateUpdated              *time.Time `json:"date_updated,omitempty"`
+	URL                      string     `json:"url,omitempty"`
+}
+
+// MembersResponse is the structured representation of a response from Twilio for multiple Members.
+type MembersResponse struct {
+	Members []Member `json:"members,omitempty"`
+	Meta    Meta     `json:"meta"`
+}
+
+// NewMember creates a new Member with the required fields. The roleSID may be left empty to use the
+// Service's default channel role.
+func NewMember(identity, roleSID string) Member {
+	return Member{
+		Identity: identity,
+		RoleSID:  roleSID,
+	}
+}
+
+// Member retrieves a specific Member of a Channel from Twilio.
+func (c *Client) Member(ctx context.Context, channelSID, memberSID string) (Member, error) {
+	var member Member
+
+	path, err := rest.Path("Channels", channelSID, "Members", memberSID)
+
+	if err != nil {
+		return member, err
+	}
+
+	data, err := c.getResource(ctx, path, nil)
+
+	if err != nil {
+		return member, err
+	}
+
+	if err := json.Unmarshal(data, &member); err != nil {
+		return member, err
+	}
+
+	return member, nil
+}
+
+// Members retrieves all Members of a Channel from Twilio, following every page of results unless a
+// Limit is given.
+func (c *Client) Members(ctx context.Context, channelSID string, opts ...ListOption) ([]Member, error) {
+	path, err := rest.Path("Channels", channelSID, "Members")
+
+	if err != nil {
+		return nil, err
+	}
+
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listAll[Member](ctx, c, listURL, opts)
+}
+
+// IterMembers lazily iterates over the Members of a Channel, fetching pages on demand.
+func (c *Client) IterMembers(ctx context.Context, channelSID string, opts ...ListOption) iter.Seq2[Member, error] {
+	path, err := rest.Path("Channels", channelSID, "Members")
+
+	if err != nil {
+		return rest.Failed[Member](err)
+	}
+
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
+		return rest.Failed[Member](err)
+	}
+
+	return iterate[Member](ctx, c, listURL, opts)
+}
+
+// AddMember adds a User to a Channel in Twilio.
+func (c *Client) AddMember(ctx context.Context, channelSID string, member Member) (Member, error) {
+	var addedMember Member
+
+	if err := rest.ValidateAttributes(member.Attributes); err != nil {
+		return addedMember, err
+	}
+
+	form := url.Values{}
+	form.Add("Identity", member.Identity)
+	if member.RoleSID != "" {
+		form.Add("RoleSid", member.RoleSID)
+	}
+	if member.Attributes != "" {
+		form.Add("Attributes", member.Attributes)
+	}
+	payload := []byte(form.Encode())
+
+	path, err := rest.Path("Channels", channelSID, "Members")
+
+	if err != nil {
+		return addedMember, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
+
+	if err != nil {
+		return addedMember, err
+	}
+
+	if err := json.Unmarshal(data, &addedMember); err != nil {
+		return addedMember, err
+	}
+
+	return addedMember, nil
+}
+
+// MemberUpdate describes changes to a Member. Only the fields that are set are sent to Twilio.
+type MemberUpdate struct {
+	RoleSID                  *string
+	LastConsumedMessageIndex *int
+	Attributes               *string // Only supported by v2.
+}
+
+// UpdateMember updates the role and read horizon of an existing Member in Twilio, leaving any field
+// not set in the update as it is.
+func (c *Client) UpdateMember(ctx context.Context, channelSID, memberSID string, update MemberUpdate) (Member, error) {
+	var updatedMember Member
+
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedMember, err
+	}
+
+	form := url.Values{}
+	rest.SetString(form, "RoleSid", update.RoleSID)
+	rest.SetInt(form, "LastConsumedMessageIndex", update.LastConsumedMessageIndex)
+	rest.SetString(form, "Attributes", update.Attributes)
+	payload := []byte(form.Encode())
+
+	path, err := rest.Path("Channels", channelSID, "Members", memberSID)
+
+	if err != nil {
+		return updatedMember, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
+
+	if err != nil {
+		return updatedMember, err
+	}
+
+	if err := json.Unmarshal(data, &updatedMember); err != nil {
+		return updatedMember, err
+	}
+
+	return updatedMember, nil
+}
+
+// RemoveMember removes a Member from a Channel in Twilio.
+func (c *Client) RemoveMember(ctx context.Context, channelSID, memberSID string) error {
+	path, err := rest.Path("Channels", channelSID, "Members", memberSID)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteResource(ctx, path)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("Received data in body of DELETE: %s", string(data))
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package twiligo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+// capturedRequest is a request received by a formServer.
+type capturedRequest struct {
+	method string
+	path   string
+	form   url.Values
+}
+
+// formServer answers every request with the given JSON body and records each request it receives.
+func formServer(t *testing.T, response string) (*twiligo.Client, *[]capturedRequest) {
+	t.Helper()
+
+	var requests []capturedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		requests = append(requests, capturedRequest{method: r.Method, path: r.URL.Path, form: form})
+
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	return twiligo.NewClient(srv.URL, "AC123", "IS0123456789abcdef0123456789abcdef", "token"), &requests
+}
+
+func TestAddMemberForm(t *testing.T) {
+	tests := []struct {
+		name   string
+		member twiligo.Member
+		want   url.Values
+	}{
+		{"default role", twiligo.NewMember("alice", ""), url.Values{"Identity": {"alice"}}},
+		{"explicit role", twiligo.NewMember("alice", "RL123"), url.Values{"Identity": {"alice"}, "RoleSid": {"RL123"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, requests := formServer(t, `{"sid": "MB123", "identity": "alice"}`)
+
+			member, err := client.AddMember(context.Background(), "CH123", test.member)
+			if err != nil {
+				t.Fatalf("AddMember: %v", err)
+			}
+
+			if member.SID != "MB123" {
+				t.Errorf("AddMember returned %+v", member)
+			}
+
+			req := (*requests)[0]
+			if req.method != http.MethodPost || req.path != "/Services/IS0123456789abcdef0123456789abcdef/Channels/CH123/Members" {
+				t.Errorf("sent %s %s", req.method, req.path)
+			}
+
+			if req.form.Encode() != test.want.Encode() {
+				t.Errorf("sent %v, want %v", req.form, test.want)
+			}
+		})
+	}
+}
+
+func TestUpdateMemberSendsOnlySetFields(t *testing.T) {
+	index := 0
+
+	tests := []struct {
+		name   string
+		update twiligo.MemberUpdate
+		want   url.Values
+	}{
+		{"nothing", twiligo.MemberUpdate{}, url.Values{}},
+		{"role", twiligo.MemberUpdate{RoleSID: twiligo.String("RL123")}, url.Values{"RoleSid": {"RL123"}}},
+		{"zero index", twiligo.MemberUpdate{LastConsumedMessageIndex: &index}, url.Values{"LastConsumedMessageIndex": {"0"}}},
+		{"clear role", twiligo.MemberUpdate{RoleSID: twiligo.String("")}, url.Values{"RoleSid": {""}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, requests := formServer(t, `{"sid": "MB123"}`)
+
+			if _, err := client.UpdateMember(context.Background(), "CH123", "MB123", test.update); err != nil {
+				t.Fatalf("UpdateMember: %v", err)
+			}
+
+			if got := (*requests)[0].form; got.Encode() != test.want.Encode() {
+				t.Errorf("sent %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMemberRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+	client := srv.Client(srv.AddService("members").SID)
+
+	channel, err := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public"))
+	if err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	role, err := client.CreateRole(ctx, twiligo.NewRole("moderator", twiligo.RoleTypeChannel, twiligo.PermissionRemoveMember))
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+
+	added, err := client.AddMember(ctx, channel.SID, twiligo.NewMember("alice", ""))
+	if err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+
+	if added.RoleSID == "" || added.RoleSID == role.SID {
+		t.Errorf("member added without a role got %q, want the default channel role", added.RoleSID)
+	}
+
+	index := 3
+	updated, err := client.UpdateMember(ctx, channel.SID, added.SID, twiligo.MemberUpdate{LastConsumedMessageIndex: &index})
+	if err != nil {
+		t.Fatalf("UpdateMember: %v", err)
+	}
+
+	if updated.RoleSID != added.RoleSID || updated.LastConsumedMessageIndex == nil || *updated.LastConsumedMessageIndex != 3 {
+		t.Errorf("partial update left %+v", updated)
+	}
+
+	updated, err = client.UpdateMember(ctx, channel.SID, added.SID, twiligo.MemberUpdate{RoleSID: &role.SID})
+	if err != nil {
+		t.Fatalf("UpdateMember: %v", err)
+	}
+
+	if updated.RoleSID != role.SID || *updated.LastConsumedMessageIndex != 3 {
+		t.Errorf("role update left %+v", updated)
+	}
+
+	got, err := client.Member(ctx, channel.SID, added.SID)
+	if err != nil || got.RoleSID != role.SID {
+		t.Errorf("Member returned %+v, %v", got, err)
+	}
+
+	if err := client.RemoveMember(ctx, channel.SID, added.SID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+
+	if members, err := client.Members(ctx, channel.SID); err != nil || len(members) != 0 {
+		t.Errorf("Members after removal returned %v, %v", members, err)
+	}
+}
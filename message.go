@@ -1,32 +1,48 @@
 package twiligo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
 )
 
 // Message is the structured representation of a Message in a Twilio Channel.
 type Message struct {
-	SID         string     `json:"sid,omitempty"`
-	AccountSID  string     `json:"account_sid,omitempty"`
-	ServiceSID  string     `json:"service_sid,omitempty"`
-	To          string     `json:"to,omitempty"`
-	DateCreated *time.Time `json:"date_created,omitempty"`
-	DateUpdated *time.Time `json:"date_updated,omitempty"`
-	WasEdited   bool       `json:"was_edited,omitempty"`
-	From        string     `json:"from,omitempty"`
-	Body        string     `json:"body,omitempty"`
-	Attributes  string     `json:"attributes,omitempty"`
-	Index       int        `json:"index,omitempty"`
-	URL         string     `json:"url,omitempty"`
+	SID           string     `json:"sid,omitempty"`
+	AccountSID    string     `json:"account_sid,omitempty"`
+	ServiceSID    string     `json:"service_sid,omitempty"`
+	ChannelSID    string     `json:"channel_sid,omitempty"`
+	To            string     `json:"to,omitempty"`
+	DateCreated   *time.Time `json:"date_created,omitempty"`
+	DateUpdated   *time.Time `json:"date_updated,omitempty"`
+	WasEdited     bool       `json:"was_edited,omitempty"`
+	From          string     `json:"from,omitempty"`
+	Body          string     `json:"body,omitempty"`
+	Attributes    string     `json:"attributes,omitempty"`
+	Index         int        `json:"index,omitempty"`
+	Type          string     `json:"type,omitempty"`            // Either "text" or "media", only returned by v2.
+	Media         *Media     `json:"media,omitempty"`           // Only returned by v2 for media messages.
+	LastUpdatedBy string     `json:"last_updated_by,omitempty"` // Only returned by v2.
+	URL           string     `json:"url,omitempty"`
+}
+
+// Media is the structured representation of the file attached to a v2 media Message.
+type Media struct {
+	SID         string `json:"sid,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Filename    string `json:"filename,omitempty"`
 }
 
 // MessagesResponse represents the structure of a response from Twilio for multiple Messages.
 type MessagesResponse struct {
 	Messages []Message `json:"messages,omitempty"`
-	//TODO: Add Meta property
+	Meta     Meta      `json:"meta"`
 }
 
 // NewMessage creates a new Message with the required fields.
@@ -39,9 +55,20 @@ func NewMessage(body, attributes, from string) Message {
 }
 
 // Message retrieves an individual message from a Channel in Twilio.
-func (c *Client) Message(channelSID, messageSID string) (Message, error) {
+func (c *Client) Message(ctx context.Context, channelSID, messageSID string) (Message, error) {
 	var message Message
-	data, err := c.getResource(fmt.Sprintf("Channels/%s/Messages/%s", channelSID, messageSID), nil)
+
+	if err := rest.ValidateSID(rest.PrefixMessage, messageSID); err != nil {
+		return message, err
+	}
+
+	path, err := rest.Path("Channels", channelSID, "Messages", messageSID)
+
+	if err != nil {
+		return message, err
+	}
+
+	data, err := c.getResource(ctx, path, nil)
 
 	if err != nil {
 		return message, err
@@ -54,32 +81,66 @@ func (c *Client) Message(channelSID, messageSID string) (Message, error) {
 	return message, nil
 }
 
-// Messages retrieves ALL Messages from a Channel in Twilio.
-func (c *Client) Messages(channelSID string) ([]Message, error) {
-	var messageRes MessagesResponse
-	data, err := c.getResource(fmt.Sprintf("Channels/%s/Messages", channelSID), nil)
+// Messages retrieves ALL Messages from a Channel in Twilio, following every page of results unless
+// a Limit is given.
+func (c *Client) Messages(ctx context.Context, channelSID string, opts ...ListOption) ([]Message, error) {
+	path, err := rest.Path("Channels", channelSID, "Messages")
 
 	if err != nil {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(data, &messageRes); err != nil {
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
 		return nil, err
 	}
 
-	return messageRes.Messages, nil
+	return listAll[Message](ctx, c, listURL, opts)
+}
+
+// IterMessages lazily iterates over the Messages in a Channel, fetching pages on demand so large
+// channels can be processed without holding every Message in memory.
+func (c *Client) IterMessages(ctx context.Context, channelSID string, opts ...ListOption) iter.Seq2[Message, error] {
+	path, err := rest.Path("Channels", channelSID, "Messages")
+
+	if err != nil {
+		return rest.Failed[Message](err)
+	}
+
+	listURL, err := c.resourceURL(path)
+
+	if err != nil {
+		return rest.Failed[Message](err)
+	}
+
+	return iterate[Message](ctx, c, listURL, opts)
 }
 
 // SendMessage sends a Message to a Channel in Twilio.
-func (c *Client) SendMessage(channelSID string, message Message) (Message, error) {
+func (c *Client) SendMessage(ctx context.Context, channelSID string, message Message) (Message, error) {
 	var sentMessage Message
 
+	if err := rest.ValidateAttributes(message.Attributes); err != nil {
+		return sentMessage, err
+	}
+
 	form := url.Values{}
 	form.Add("Body", message.Body)
 	form.Add("Attributes", message.Attributes)
 	form.Add("From", message.From)
+	if message.Media != nil && message.Media.SID != "" {
+		form.Add("MediaSid", message.Media.SID)
+	}
 	payload := []byte(form.Encode())
-	data, err := c.postResource(fmt.Sprintf("Channels/%s/Messages", channelSID), payload, getFormHeader())
+
+	path, err := rest.Path("Channels", channelSID, "Messages")
+
+	if err != nil {
+		return sentMessage, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
 
 	if err != nil {
 		return sentMessage, err
@@ -92,16 +153,37 @@ func (c *Client) SendMessage(channelSID string, message Message) (Message, error
 	return sentMessage, nil
 }
 
-// UpdateMessage updates a specific Message within a Channel in Twilio.
-func (c *Client) UpdateMessage(channelSID string, message Message) (Message, error) {
+// MessageUpdate describes changes to a Message. Only the fields that are set are sent to Twilio.
+type MessageUpdate struct {
+	Body       *string
+	Attributes *string
+}
+
+// UpdateMessage updates a specific Message within a Channel in Twilio, leaving any field not set in
+// the update as it is.
+func (c *Client) UpdateMessage(ctx context.Context, channelSID, messageSID string, update MessageUpdate) (Message, error) {
 	var updatedMessage Message
 
+	if err := rest.ValidateSID(rest.PrefixMessage, messageSID); err != nil {
+		return updatedMessage, err
+	}
+
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedMessage, err
+	}
+
 	form := url.Values{}
-	form.Add("Body", message.Body)
-	form.Add("Attributes", message.Attributes)
+	rest.SetString(form, "Body", update.Body)
+	rest.SetString(form, "Attributes", update.Attributes)
 	payload := []byte(form.Encode())
 
-	data, err := c.postResource(fmt.Sprintf("Channels/%s/Messages/%s", channelSID, message.SID), payload, getFormHeader())
+	path, err := rest.Path("Channels", channelSID, "Messages", messageSID)
+
+	if err != nil {
+		return updatedMessage, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
 
 	if err != nil {
 		return updatedMessage, err
@@ -115,8 +197,18 @@ func (c *Client) UpdateMessage(channelSID string, message Message) (Message, err
 }
 
 // DeleteMessage deletes a specific Message within a Channel in Twilio.
-func (c *Client) DeleteMessage(channelSID, messageSID string) error {
-	data, err := c.deleteResource(fmt.Sprintf("Channels/%s/Messages/%s", channelSID, messageSID))
+func (c *Client) DeleteMessage(ctx context.Context, channelSID, messageSID string) error {
+	if err := rest.ValidateSID(rest.PrefixMessage, messageSID); err != nil {
+		return err
+	}
+
+	path, err := rest.Path("Channels", channelSID, "Messages", messageSID)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteResource(ctx, path)
 
 	if err != nil {
 		return err
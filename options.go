@@ -0,0 +1,47 @@
+package twiligo
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WithHTTPClient sets the http.Client used to make requests. By default http.DefaultClient is used.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.http = httpClient
+		}
+	}
+}
+
+// WithBaseURL sets the root of the Twilio API the Client talks to, e.g. to point it at a proxy or a
+// fake server in tests.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithServiceSID binds the Client to a Service, which every Channel, Message, User, Member and Role
+// method operates within.
+func WithServiceSID(serviceSID string) Option {
+	return func(c *Client) {
+		c.serviceSID = serviceSID
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout bounds every individual attempt at a request, independently of any deadline on the
+// context passed to a method. Retries get a fresh timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
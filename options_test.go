@@ -0,0 +1,178 @@
+package twiligo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eriktate/twiligo"
+)
+
+const (
+	serviceA = "IS0123456789abcdef0123456789abcdef"
+	serviceB = "ISfedcba9876543210fedcba9876543210"
+)
+
+// seenRequest is what a requestLog keeps of each request.
+type seenRequest struct {
+	path      string
+	userAgent string
+	username  string
+	password  string
+}
+
+// requestLog serves empty Channel lists and records every request made to it.
+type requestLog struct {
+	mu       sync.Mutex
+	requests []seenRequest
+}
+
+func newRequestLog(t *testing.T) (*httptest.Server, *requestLog) {
+	t.Helper()
+
+	log := &requestLog{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+
+		log.mu.Lock()
+		log.requests = append(log.requests, seenRequest{r.URL.Path, r.UserAgent(), username, password})
+		log.mu.Unlock()
+
+		w.Write([]byte(`{"channels": [], "meta": {"key": "channels"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, log
+}
+
+func (l *requestLog) last() seenRequest {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.requests[len(l.requests)-1]
+}
+
+// countingTransport counts the requests passed to the default transport.
+type countingTransport struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.count++
+	c.mu.Unlock()
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestNewClientKeepsWorking(t *testing.T) {
+	srv, log := newRequestLog(t)
+
+	client := twiligo.NewClient(srv.URL, "AC123", serviceA, "token")
+	if _, err := client.Channels(context.Background()); err != nil {
+		t.Fatalf("Channels: %v", err)
+	}
+
+	want := seenRequest{"/Services/" + serviceA + "/Channels", twiligo.DefaultUserAgent, "AC123", "token"}
+	if got := log.last(); got != want {
+		t.Errorf("sent %+v, want %+v", got, want)
+	}
+
+	if client.ServiceSID() != serviceA {
+		t.Errorf("ServiceSID = %q, want %q", client.ServiceSID(), serviceA)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	srv, log := newRequestLog(t)
+	transport := &countingTransport{}
+
+	client := twiligo.New("AC123", "token",
+		twiligo.WithBaseURL(srv.URL+"/"),
+		twiligo.WithServiceSID(serviceA),
+		twiligo.WithHTTPClient(&http.Client{Transport: transport}),
+		twiligo.WithUserAgent("my-app/1.0"))
+
+	if _, err := client.Channels(context.Background()); err != nil {
+		t.Fatalf("Channels: %v", err)
+	}
+
+	if got := log.last(); got.userAgent != "my-app/1.0" || got.path != "/Services/"+serviceA+"/Channels" {
+		t.Errorf("sent %+v, want the custom User-Agent to the trimmed base URL", got)
+	}
+
+	if transport.count != 1 {
+		t.Errorf("custom http.Client made %d requests, want 1", transport.count)
+	}
+}
+
+func TestWithTimeoutAppliesPerAttempt(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		slow := attempts == 1
+		mu.Unlock()
+
+		if slow {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+
+		w.Write([]byte(`{"channels": [], "meta": {"key": "channels"}}`))
+	}))
+	defer srv.Close()
+
+	client := twiligo.New("AC123", "token", twiligo.WithBaseURL(srv.URL), twiligo.WithServiceSID(serviceA),
+		twiligo.WithTimeout(50*time.Millisecond),
+		twiligo.WithRetryPolicy(twiligo.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}))
+
+	// The first attempt times out on its own and the retry gets a fresh timeout, while the
+	// context passed in never expires.
+	if _, err := client.Channels(context.Background()); err != nil {
+		t.Fatalf("Channels: %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("made %d attempts, want 2", attempts)
+	}
+}
+
+func TestForService(t *testing.T) {
+	srv, log := newRequestLog(t)
+	transport := &countingTransport{}
+
+	parent := twiligo.New("AC123", "token", twiligo.WithBaseURL(srv.URL), twiligo.WithServiceSID(serviceA),
+		twiligo.WithHTTPClient(&http.Client{Transport: transport}), twiligo.WithUserAgent("my-app/1.0"))
+	child := parent.ForService(serviceB)
+
+	if parent.ServiceSID() != serviceA || child.ServiceSID() != serviceB {
+		t.Fatalf("parent is scoped to %q and child to %q", parent.ServiceSID(), child.ServiceSID())
+	}
+
+	for _, test := range []struct {
+		client  *twiligo.Client
+		service string
+	}{{child, serviceB}, {parent, serviceA}, {child.ForService(serviceA), serviceA}, {child, serviceB}} {
+		if _, err := test.client.Channels(context.Background()); err != nil {
+			t.Fatalf("Channels: %v", err)
+		}
+
+		want := seenRequest{"/Services/" + test.service + "/Channels", "my-app/1.0", "AC123", "token"}
+		if got := log.last(); got != want {
+			t.Errorf("sent %+v, want %+v", got, want)
+		}
+	}
+
+	if transport.count != 4 {
+		t.Errorf("shared transport made %d requests, want 4", transport.count)
+	}
+}
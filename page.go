@@ -0,0 +1,226 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Meta is the structured representation of the paging information Twilio includes with every list
+// response.
+type Meta struct {
+	Page            int    `json:"page"`
+	PageSize        int    `json:"page_size"`
+	FirstPageURL    string `json:"first_page_url,omitempty"`
+	PreviousPageURL string `json:"previous_page_url,omitempty"`
+	URL             string `json:"url,omitempty"`
+	NextPageURL     string `json:"next_page_url,omitempty"`
+	Key             string `json:"key"` // The property of the response holding the page's items.
+}
+
+// ListOption configures how a list method pages through results.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	pageSize   int
+	limit      int
+	descending bool
+}
+
+// PageSize sets how many items are requested from Twilio per page. Twilio caps this at 100.
+func PageSize(size int) ListOption {
+	return func(o *listOptions) {
+		o.pageSize = size
+	}
+}
+
+// Limit stops a list method after it has returned the given number of items.
+func Limit(limit int) ListOption {
+	return func(o *listOptions) {
+		o.limit = limit
+	}
+}
+
+// Descending lists newest first, for lists that take Twilio's Order parameter such as Messages.
+func Descending() ListOption {
+	return func(o *listOptions) {
+		o.descending = true
+	}
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	var options listOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// No reason to fetch a big page when we only want a few items.
+	if options.pageSize == 0 && options.limit > 0 && options.limit < 100 {
+		options.pageSize = options.limit
+	}
+
+	return options
+}
+
+// firstPageURL adds the paging and ordering parameters for the first request of a list.
+func (o listOptions) firstPageURL(rawURL string) string {
+	params := url.Values{}
+	if o.pageSize > 0 {
+		params.Set("PageSize", strconv.Itoa(o.pageSize))
+	}
+
+	if o.descending {
+		params.Set("Order", "desc")
+	}
+
+	if len(params) == 0 {
+		return rawURL
+	}
+
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+
+	return rawURL + sep + params.Encode()
+}
+
+// fetchPage retrieves a single page of a list, using the key from its meta block to find the items.
+func fetchPage[T any](ctx context.Context, c *Client, url string) ([]T, Meta, error) {
+	var page map[string]json.RawMessage
+	var meta Meta
+
+	data, err := c.get(ctx, url, nil)
+
+	if err != nil {
+		return nil, meta, err
+	}
+
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, meta, err
+	}
+
+	if raw, ok := page["meta"]; ok {
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, meta, err
+		}
+	}
+
+	raw, ok := page[meta.Key]
+	if !ok {
+		return nil, meta, fmt.Errorf("List response from %s has no %q property", url, meta.Key)
+	}
+
+	var items []T
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, meta, err
+	}
+
+	return items, meta, nil
+}
+
+// iterate lazily walks a list starting at the given URL, only requesting the next page once every
+// item of the current one has been yielded. Stopping the iteration early skips the remaining pages.
+func iterate[T any](ctx context.Context, c *Client, url string, opts []ListOption) iter.Seq2[T, error] {
+	options := newListOptions(opts)
+
+	return func(yield func(T, error) bool) {
+		var count int
+
+		next := options.firstPageURL(url)
+		for next != "" {
+			items, meta, err := fetchPage[T](ctx, c, next)
+
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+
+				count++
+				if options.limit > 0 && count >= options.limit {
+					return
+				}
+			}
+
+			if next, err = nextPageURL(next, meta); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// List retrieves every item of a list at an absolute URL of any Twilio API, following pagination.
+// The items are found using the key from the response's meta block.
+func List[T any](ctx context.Context, c *Client, url string, opts ...ListOption) ([]T, error) {
+	return listAll[T](ctx, c, url, opts)
+}
+
+// Iterate lazily walks a list at an absolute URL of any Twilio API, fetching pages as needed.
+func Iterate[T any](ctx context.Context, c *Client, url string, opts ...ListOption) iter.Seq2[T, error] {
+	return iterate[T](ctx, c, url, opts)
+}
+
+// listAll follows next_page_url from the given URL until every item has been retrieved or the limit
+// has been reached.
+func listAll[T any](ctx context.Context, c *Client, url string, opts []ListOption) ([]T, error) {
+	var all []T
+
+	for item, err := range iterate[T](ctx, c, url, opts) {
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, item)
+	}
+
+	return all, nil
+}
+
+// nextPageURL resolves the next_page_url of the page fetched from current. Twilio answers with URLs
+// on its own host, so only their path and query are used: later pages go through the same base URL
+// as the first, e.g. a proxy, and credentials are never sent to a host named by a response.
+func nextPageURL(current string, meta Meta) (string, error) {
+	if meta.NextPageURL == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(current)
+
+	if err != nil {
+		return "", err
+	}
+
+	next, err := url.Parse(meta.NextPageURL)
+
+	if err != nil {
+		return "", fmt.Errorf("Invalid next_page_url %q: %w", meta.NextPageURL, err)
+	}
+
+	// A proxy may serve the API below a path prefix Twilio doesn't know about, which shows as the
+	// difference between the URL requested and the page's own url.
+	var prefix string
+	if self, err := url.Parse(meta.URL); err == nil && self.Path != "" {
+		if trimmed, ok := strings.CutSuffix(base.EscapedPath(), self.EscapedPath()); ok {
+			prefix = trimmed
+		}
+	}
+
+	resolved := base.Scheme + "://" + base.Host + prefix + next.EscapedPath()
+	if next.RawQuery != "" {
+		resolved += "?" + next.RawQuery
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,171 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+// pagedClient returns a Client for a fake Server holding the given number of Channels, and the
+// number of list requests made through it.
+func pagedClient(t *testing.T, channels int) (*twiligo.Client, *atomic.Int32) {
+	t.Helper()
+
+	srv := twiligotest.NewServer()
+	t.Cleanup(srv.Close)
+	service := srv.AddService("paged")
+
+	for i := range channels {
+		name := fmt.Sprintf("channel-%d", i)
+		if _, err := srv.Client(service.SID).CreateChannel(context.Background(), twiligo.NewChannel(name, name, "", "public")); err != nil {
+			t.Fatalf("CreateChannel: %v", err)
+		}
+	}
+
+	// Count requests on the Server itself, since the next page URLs it hands out point there.
+	var requests atomic.Int32
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler.ServeHTTP(w, r)
+	})
+
+	return srv.Client(service.SID), &requests
+}
+
+func TestListPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []twiligo.ListOption
+		items    int
+		requests int32
+	}{
+		{"all pages", []twiligo.ListOption{twiligo.PageSize(3)}, 7, 3},
+		{"limit across pages", []twiligo.ListOption{twiligo.PageSize(3), twiligo.Limit(4)}, 4, 2},
+		{"limit sizes the page", []twiligo.ListOption{twiligo.Limit(2)}, 2, 1},
+		{"limit over total", []twiligo.ListOption{twiligo.PageSize(5), twiligo.Limit(20)}, 7, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, requests := pagedClient(t, 7)
+
+			channels, err := client.Channels(context.Background(), test.opts...)
+			if err != nil {
+				t.Fatalf("Channels: %v", err)
+			}
+
+			if len(channels) != test.items || requests.Load() != test.requests {
+				t.Errorf("got %d channels in %d requests, want %d in %d", len(channels), requests.Load(), test.items, test.requests)
+			}
+
+			for i, channel := range channels {
+				if want := fmt.Sprintf("channel-%d", i); channel.UniqueName != want {
+					t.Errorf("channel %d is %s, want %s", i, channel.UniqueName, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIterStopsFetchingOnBreak(t *testing.T) {
+	client, requests := pagedClient(t, 7)
+
+	var seen []string
+	for channel, err := range client.IterChannels(context.Background(), twiligo.PageSize(3)) {
+		if err != nil {
+			t.Fatalf("IterChannels: %v", err)
+		}
+
+		seen = append(seen, channel.UniqueName)
+		if len(seen) == 2 {
+			break
+		}
+	}
+
+	if len(seen) != 2 || requests.Load() != 1 {
+		t.Errorf("saw %v in %d requests, want two channels from one request", seen, requests.Load())
+	}
+}
+
+func TestIterYieldsPageErrors(t *testing.T) {
+	client, _ := flakyClient(t, http.StatusNotFound, 1)
+
+	var calls int
+	for _, err := range client.IterChannels(context.Background()) {
+		calls++
+		if !errors.Is(err, twiligo.ErrNotFound) {
+			t.Errorf("IterChannels yielded %v, want ErrNotFound", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("IterChannels yielded %d times, want once", calls)
+	}
+}
+
+// twilioPages serves the Channels of a Service one per page, answering with URLs on Twilio's host
+// the way Twilio does whatever host the request came in on.
+func twilioPages(t *testing.T, channels int) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Errorf("%s has no credentials", r.URL)
+		}
+
+		paths = append(paths, r.URL.RequestURI())
+		page, _ := strconv.Atoi(r.URL.Query().Get("Page"))
+		pageURL := func(n int) string {
+			return fmt.Sprintf("https://chat.twilio.com/v2/Services/IS123/Channels?PageSize=1&Page=%d", n)
+		}
+
+		next := ""
+		if page+1 < channels {
+			next = pageURL(page + 1)
+		}
+
+		fmt.Fprintf(w, `{"channels": [{"unique_name": "channel-%d"}], "meta": {"key": "channels", "url": %q, "next_page_url": %q}}`, page, pageURL(page), next)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &paths
+}
+
+func TestListKeepsBaseURLForNextPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		path    string
+	}{
+		{"twilio's own host", "/v2", "/v2/Services/IS123/Channels"},
+		{"proxy below a path prefix", "/twilio/v2", "/twilio/v2/Services/IS123/Channels"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv, paths := twilioPages(t, 3)
+			client := twiligo.NewClient(srv.URL+test.baseURL, "AC123", "IS123", "token")
+
+			channels, err := twiligo.List[twiligo.Channel](context.Background(), client, srv.URL+test.path, twiligo.PageSize(1))
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+
+			want := []string{test.path + "?PageSize=1", test.path + "?PageSize=1&Page=1", test.path + "?PageSize=1&Page=2"}
+			if len(channels) != 3 || !slices.Equal(*paths, want) {
+				t.Errorf("got %d channels from %v, want 3 from %v", len(channels), *paths, want)
+			}
+		})
+	}
+}
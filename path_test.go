@@ -0,0 +1,71 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestEmptyIDsAreRejected(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+
+	client := srv.Client(srv.AddService("ids").SID)
+	if _, err := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public")); err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	calls := map[string]func() error{
+		"Channel": func() error {
+			_, err := client.Channel(ctx, "")
+			return err
+		},
+		"DeleteChannel": func() error {
+			return client.DeleteChannel(ctx, "")
+		},
+		"User": func() error {
+			_, err := client.User(ctx, "")
+			return err
+		},
+		"DeleteUser": func() error {
+			return client.DeleteUser(ctx, "")
+		},
+		"Messages": func() error {
+			_, err := client.Messages(ctx, "")
+			return err
+		},
+		"Member": func() error {
+			_, err := client.Member(ctx, "general", "")
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, twiligo.ErrMissingID) {
+			t.Errorf("%s with an empty id returned %v, want ErrMissingID", name, err)
+		}
+	}
+}
+
+func TestIDsAreEscaped(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+
+	client := srv.Client(srv.AddService("ids").SID)
+	if _, err := client.CreateUser(ctx, twiligo.User{Identity: "alice/admin?x=1#top"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	user, err := client.User(ctx, "alice/admin?x=1#top")
+	if err != nil {
+		t.Fatalf("User: %v", err)
+	}
+	if user.Identity != "alice/admin?x=1#top" {
+		t.Errorf("Identity = %q", user.Identity)
+	}
+}
@@ -0,0 +1,155 @@
+package twiligo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how a Client retries requests that fail with a transient error. 429s are
+// retried for every verb, other failures only for idempotent verbs unless the request context was
+// marked with RetrySafe. Errors with a Permanent method returning true, e.g. from a custom
+// http.RoundTripper, are never retried.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first one. Values below 2 disable retries.
+	MinBackoff  time.Duration // Backoff before the first retry, doubled for each one after.
+	MaxBackoff  time.Duration // Upper bound on any single backoff, including Retry-After.
+
+	// OnRetry, if set, is called before the Client sleeps ahead of another attempt.
+	OnRetry func(RetryEvent)
+}
+
+// DefaultRetryPolicy is a reasonable starting point for retrying 429s and transient 5xx responses.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  250 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// RetryEvent describes a failed attempt that is about to be retried.
+type RetryEvent struct {
+	Attempt int // The attempt that failed, starting at 1.
+	Method  string
+	URL     string
+	Err     error
+	Wait    time.Duration // How long the Client will wait before the next attempt.
+}
+
+// WithRetryPolicy sets the RetryPolicy used by the Client. By default requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+type retrySafeKey struct{}
+
+// RetrySafe marks requests made with the returned context as safe to retry even when their verb
+// isn't idempotent, e.g. a POST that creates a Channel with a UniqueName.
+func RetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+func isRetrySafe(ctx context.Context) bool {
+	safe, _ := ctx.Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+// permanent is implemented by errors that fail the same way however often the request is retried.
+type permanent interface {
+	Permanent() bool
+}
+
+// retryable reports whether a request that failed with err may be attempted again.
+func (p RetryPolicy) retryable(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var perm permanent
+	if errors.As(err, &perm) && perm.Permanent() {
+		return false
+	}
+
+	var apiErr *APIError
+	isAPIErr := errors.As(err, &apiErr)
+
+	// A 429 means Twilio turned the request away without processing it, so even a POST can safely
+	// be sent again.
+	if isAPIErr && apiErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+	default:
+		if !isRetrySafe(ctx) {
+			return false
+		}
+	}
+
+	if !isAPIErr {
+		// Transport level failures (resets, timeouts) are worth another try.
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns how long to wait after the given failed attempt. Exponential backoff is jittered
+// to avoid synchronized retries, and a Retry-After from Twilio takes precedence when it's longer.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	// Without a MinBackoff there is no base delay, and doubling stops before it would overflow.
+	wait := p.MinBackoff
+	for i := 1; i < attempt && wait > 0 && wait <= math.MaxInt64/2; i++ {
+		wait *= 2
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if wait > 0 {
+		wait = wait/2 + rand.N(wait/2+1)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > wait {
+		wait = apiErr.RetryAfter
+	}
+
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	return wait
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: delay seconds and an HTTP date.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,186 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+var fastRetries = twiligo.RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+}
+
+// flakyClient returns a Client for a fake Server whose first failures requests are answered with
+// status instead, and the number of requests made. The Client retries with fastRetries unless opts
+// say otherwise.
+func flakyClient(t *testing.T, status, failures int, opts ...twiligo.Option) (*twiligo.Client, *atomic.Int32) {
+	t.Helper()
+
+	srv := twiligotest.NewServer()
+	t.Cleanup(srv.Close)
+	service := srv.AddService("flaky")
+
+	var requests atomic.Int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(requests.Add(1)) <= failures {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"code": 20429, "message": "Too Many Requests", "status": 429}`))
+			return
+		}
+
+		srv.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(flaky.Close)
+
+	opts = append([]twiligo.Option{twiligo.WithRetryPolicy(fastRetries)}, opts...)
+	return twiligo.NewClient(flaky.URL, srv.AccountSID, service.SID, srv.AuthToken, opts...), &requests
+}
+
+func TestRetryPOSTOnTooManyRequests(t *testing.T) {
+	client, requests := flakyClient(t, http.StatusTooManyRequests, 2)
+
+	if _, err := client.CreateChannel(context.Background(), twiligo.NewChannel("General", "general", "", "public")); err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("made %d requests, want 3", got)
+	}
+}
+
+func TestNoRetryPOSTOnServerError(t *testing.T) {
+	client, requests := flakyClient(t, http.StatusServiceUnavailable, 1)
+
+	_, err := client.CreateChannel(context.Background(), twiligo.NewChannel("General", "general", "", "public"))
+
+	var apiErr *twiligo.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("CreateChannel returned %v, want the 503", err)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("made %d requests, want 1", got)
+	}
+}
+
+func TestRetrySafePOSTOnServerError(t *testing.T) {
+	client, requests := flakyClient(t, http.StatusServiceUnavailable, 1)
+
+	ctx := twiligo.RetrySafe(context.Background())
+	if _, err := client.CreateChannel(ctx, twiligo.NewChannel("General", "general", "", "public")); err != nil {
+		t.Fatalf("CreateChannel: %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("made %d requests, want 2", got)
+	}
+}
+
+func TestRetryGETGivesUpAfterMaxAttempts(t *testing.T) {
+	client, requests := flakyClient(t, http.StatusBadGateway, 10)
+
+	_, err := client.Channels(context.Background())
+	if err == nil {
+		t.Fatal("Channels succeeded, want the 502")
+	}
+
+	if got := requests.Load(); got != int32(fastRetries.MaxAttempts) {
+		t.Errorf("made %d requests, want %d", got, fastRetries.MaxAttempts)
+	}
+}
+
+func TestRetryOnRetryIsCalled(t *testing.T) {
+	var events []twiligo.RetryEvent
+	policy := fastRetries
+	policy.OnRetry = func(event twiligo.RetryEvent) {
+		events = append(events, event)
+	}
+
+	client, _ := flakyClient(t, http.StatusTooManyRequests, 1, twiligo.WithRetryPolicy(policy))
+
+	if _, err := client.Users(context.Background()); err != nil {
+		t.Fatalf("Users: %v", err)
+	}
+
+	if len(events) != 1 || events[0].Attempt != 1 || events[0].Method != http.MethodGet {
+		t.Errorf("OnRetry got %+v, want a single event for the first GET", events)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   twiligo.RetryPolicy
+		min, max time.Duration
+	}{
+		{"no base delay", twiligo.RetryPolicy{MaxAttempts: 3, MaxBackoff: 30 * time.Second}, 0, 0},
+		{"capped", twiligo.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Hour, MaxBackoff: 4 * time.Millisecond}, 2 * time.Millisecond, 4 * time.Millisecond},
+		{"doubling would overflow", twiligo.RetryPolicy{MaxAttempts: 3, MinBackoff: math.MaxInt64 / 2, MaxBackoff: 4 * time.Millisecond}, 2 * time.Millisecond, 4 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var waits []time.Duration
+			policy := test.policy
+			policy.OnRetry = func(event twiligo.RetryEvent) {
+				waits = append(waits, event.Wait)
+			}
+
+			client, _ := flakyClient(t, http.StatusServiceUnavailable, 2, twiligo.WithRetryPolicy(policy))
+			if _, err := client.Channels(context.Background()); err != nil {
+				t.Fatalf("Channels: %v", err)
+			}
+
+			if len(waits) != 2 {
+				t.Fatalf("retried %d times, want 2", len(waits))
+			}
+
+			for _, wait := range waits {
+				if wait < test.min || wait > test.max {
+					t.Errorf("waited %s, want between %s and %s", wait, test.min, test.max)
+				}
+			}
+		})
+	}
+}
+
+// permanentTransport fails every request with an error that marks itself permanent.
+type permanentTransport struct {
+	requests int
+}
+
+type permanentError struct{}
+
+func (permanentError) Error() string   { return "permanent failure" }
+func (permanentError) Permanent() bool { return true }
+
+func (p *permanentTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	p.requests++
+	return nil, permanentError{}
+}
+
+func TestNoRetryOnPermanentError(t *testing.T) {
+	transport := &permanentTransport{}
+	client := twiligo.NewClient("https://chat.example.com/v2", "AC123", "IS0123456789abcdef0123456789abcdef", "token",
+		twiligo.WithHTTPClient(&http.Client{Transport: transport}), twiligo.WithRetryPolicy(fastRetries))
+
+	if _, err := client.Channels(context.Background()); !errors.As(err, new(permanentError)) {
+		t.Fatalf("Channels returned %v, want the permanent error", err)
+	}
+
+	if transport.requests != 1 {
+		t.Errorf("made %d requests, want 1", transport.requests)
+	}
+}
@@ -0,0 +1,218 @@
+package twiligo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
+)
+
+// RoleType determines the scope a Role's permissions apply to.
+type RoleType string
+
+// The types of Role supported by Twilio.
+const (
+	RoleTypeChannel    RoleType = "channel"
+	RoleTypeDeployment RoleType = "deployment"
+)
+
+// Permission is an action a Role allows its Users or Members to take.
+type Permission string
+
+// Permissions that apply to deployment Roles, which are assigned to Users.
+const (
+	PermissionCreateChannel   Permission = "createChannel"
+	PermissionJoinChannel     Permission = "joinChannel"
+	PermissionDestroyChannel  Permission = "destroyChannel"
+	PermissionEditOwnUserInfo Permission = "editOwnUserInfo"
+	PermissionEditAnyUserInfo Permission = "editAnyUserInfo"
+)
+
+// Permissions that apply to channel Roles, which are assigned to Members.
+const (
+	PermissionSendMessage              Permission = "sendMessage"
+	PermissionSendMediaMessage         Permission = "sendMediaMessage"
+	PermissionLeaveChannel             Permission = "leaveChannel"
+	PermissionAddMember                Permission = "addMember"
+	PermissionInviteMember             Permission = "inviteMember"
+	PermissionRemoveMember             Permission = "removeMember"
+	PermissionEditChannelName          Permission = "editChannelName"
+	PermissionEditChannelAttributes    Permission = "editChannelAttributes"
+	PermissionEditNotificationLevel    Permission = "editNotificationLevel"
+	PermissionEditOwnMessage           Permission = "editOwnMessage"
+	PermissionEditOwnMessageAttributes Permission = "editOwnMessageAttributes"
+	PermissionEditAnyMessage           Permission = "editAnyMessage"
+	PermissionEditAnyMessageAttributes Permission = "editAnyMessageAttributes"
+	PermissionDeleteOwnMessage         Permission = "deleteOwnMessage"
+	PermissionDeleteAnyMessage         Permission = "deleteAnyMessage"
+	PermissionEditOwnMemberAttributes  Permission = "editOwnMemberAttributes"
+	PermissionEditAnyMemberAttributes  Permission = "editAnyMemberAttributes"
+)
+
+// Role is the structured representation of a Twilio Role.
+type Role struct {
+	SID          string       `json:"sid,omitempty"`
+	AccountSID   string       `json:"account_sid,omitempty"`
+	ServiceSID   string       `json:"service_sid,omitempty"`
+	FriendlyName string       `json:"friendly_name,omitempty"`
+	Type         RoleType     `json:"type,omitempty"`
+	Permissions  []Permission `json:"permissions,omitempty"`
+	DateCreated  *time.Time   `json:"date_created,omitempty"`
+	DateUpdated  *time.Time   `json:"date_updated,omitempty"`
+	URL          string       `json:"url,omitempty"`
+}
+
+// RolesResponse is the structured representation of a response from Twilio for multiple Roles.
+type RolesResponse struct {
+	Roles []Role `json:"roles,omitempty"`
+	Meta  Meta   `json:"meta"`
+}
+
+// NewRole creates a new Role with the required fields.
+func NewRole(friendlyName string, roleType RoleType, permissions ...Permission) Role {
+	return Role{
+		FriendlyName: friendlyName,
+		Type:         roleType,
+		Permissions:  permissions,
+	}
+}
+
+// Role retrieves a specific Role from Twilio.
+func (c *Client) Role(ctx context.Context, sid string) (Role, error) {
+	var role Role
+
+	if err := rest.ValidateSID(rest.PrefixRole, sid); err != nil {
+		return role, err
+	}
+
+	path, err := rest.Path("Roles", sid)
+
+	if err != nil {
+		return role, err
+	}
+
+	data, err := c.getResource(ctx, path, nil)
+
+	if err != nil {
+		return role, err
+	}
+
+	if err := json.Unmarshal(data, &role); err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+// Roles retrieves all Roles in the Client's Service, following every page of results unless a Limit
+// is given.
+func (c *Client) Roles(ctx context.Context, opts ...ListOption) ([]Role, error) {
+	listURL, err := c.resourceURL("Roles")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listAll[Role](ctx, c, listURL, opts)
+}
+
+// IterRoles lazily iterates over the Roles in the Client's Service, fetching pages on demand.
+func (c *Client) IterRoles(ctx context.Context, opts ...ListOption) iter.Seq2[Role, error] {
+	listURL, err := c.resourceURL("Roles")
+
+	if err != nil {
+		return rest.Failed[Role](err)
+	}
+
+	return iterate[Role](ctx, c, listURL, opts)
+}
+
+// CreateRole creates a new Role in Twilio.
+func (c *Client) CreateRole(ctx context.Context, role Role) (Role, error) {
+	var createdRole Role
+
+	form := url.Values{}
+	form.Add("FriendlyName", role.FriendlyName)
+	form.Add("Type", string(role.Type))
+	addPermissions(form, role.Permissions)
+	payload := []byte(form.Encode())
+
+	data, err := c.postResource(ctx, "Roles", payload, getFormHeader())
+
+	if err != nil {
+		return createdRole, err
+	}
+
+	if err := json.Unmarshal(data, &createdRole); err != nil {
+		return createdRole, err
+	}
+
+	return createdRole, nil
+}
+
+// UpdateRole replaces the permissions of an existing Role in Twilio. Twilio doesn't allow any other
+// property of a Role to change.
+func (c *Client) UpdateRole(ctx context.Context, role Role) (Role, error) {
+	var updatedRole Role
+
+	if err := rest.ValidateSID(rest.PrefixRole, role.SID); err != nil {
+		return updatedRole, err
+	}
+
+	form := url.Values{}
+	addPermissions(form, role.Permissions)
+	payload := []byte(form.Encode())
+
+	path, err := rest.Path("Roles", role.SID)
+
+	if err != nil {
+		return updatedRole, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
+
+	if err != nil {
+		return updatedRole, err
+	}
+
+	if err := json.Unmarshal(data, &updatedRole); err != nil {
+		return updatedRole, err
+	}
+
+	return updatedRole, nil
+}
+
+// DeleteRole deletes a Role from Twilio.
+func (c *Client) DeleteRole(ctx context.Context, sid string) error {
+	if err := rest.ValidateSID(rest.PrefixRole, sid); err != nil {
+		return err
+	}
+
+	path, err := rest.Path("Roles", sid)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteResource(ctx, path)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		return fmt.Errorf("Received data in body of DELETE: %s", string(data))
+	}
+
+	return nil
+}
+
+func addPermissions(form url.Values, permissions []Permission) {
+	for _, permission := range permissions {
+		form.Add("Permission", string(permission))
+	}
+}
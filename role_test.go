@@ -0,0 +1,84 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestCreateRoleRepeatsPermission(t *testing.T) {
+	client, requests := formServer(t, `{"sid": "RL123", "permissions": ["sendMessage", "leaveChannel"]}`)
+
+	role := twiligo.NewRole("member", twiligo.RoleTypeChannel, twiligo.PermissionSendMessage, twiligo.PermissionLeaveChannel)
+	created, err := client.CreateRole(context.Background(), role)
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+
+	if want := role.Permissions; !slices.Equal(created.Permissions, want) {
+		t.Errorf("CreateRole returned permissions %v, want %v", created.Permissions, want)
+	}
+
+	form := (*requests)[0].form
+	if got := form["Permission"]; !slices.Equal(got, []string{"sendMessage", "leaveChannel"}) {
+		t.Errorf("sent Permission %q, want each permission as its own value", got)
+	}
+
+	if form.Get("FriendlyName") != "member" || form.Get("Type") != "channel" {
+		t.Errorf("sent %v", form)
+	}
+}
+
+func TestUpdateRoleSendsOnlyPermissions(t *testing.T) {
+	client, requests := formServer(t, `{"sid": "RL0123456789abcdef0123456789abcdef"}`)
+
+	role := twiligo.NewRole("member", twiligo.RoleTypeChannel, twiligo.PermissionSendMessage)
+	role.SID = "RL0123456789abcdef0123456789abcdef"
+	if _, err := client.UpdateRole(context.Background(), role); err != nil {
+		t.Fatalf("UpdateRole: %v", err)
+	}
+
+	req := (*requests)[0]
+	if req.method != http.MethodPost || req.form.Encode() != "Permission=sendMessage" {
+		t.Errorf("sent %s %v, want only the permissions", req.method, req.form)
+	}
+}
+
+func TestRoleRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+	client := srv.Client(srv.AddService("roles").SID)
+
+	created, err := client.CreateRole(ctx, twiligo.NewRole("moderator", twiligo.RoleTypeChannel, twiligo.PermissionSendMessage, twiligo.PermissionRemoveMember))
+	if err != nil {
+		t.Fatalf("CreateRole: %v", err)
+	}
+
+	created.Permissions = []twiligo.Permission{twiligo.PermissionSendMessage}
+	if _, err := client.UpdateRole(ctx, created); err != nil {
+		t.Fatalf("UpdateRole: %v", err)
+	}
+
+	got, err := client.Role(ctx, created.SID)
+	if err != nil {
+		t.Fatalf("Role: %v", err)
+	}
+
+	if got.FriendlyName != "moderator" || got.Type != twiligo.RoleTypeChannel || !slices.Equal(got.Permissions, created.Permissions) {
+		t.Errorf("Role returned %+v", got)
+	}
+
+	if err := client.DeleteRole(ctx, created.SID); err != nil {
+		t.Fatalf("DeleteRole: %v", err)
+	}
+
+	if _, err := client.Role(ctx, created.SID); !errors.Is(err, twiligo.ErrNotFound) {
+		t.Errorf("Role after deletion returned %v, want ErrNotFound", err)
+	}
+}
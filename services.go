@@ -1,35 +1,42 @@
 package twiligo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
-	"strconv"
 	"time"
-)
 
-// TODO: This is missing some things because the docs are not clear on structure. Need to research.
+	"github.com/eriktate/twiligo/internal/rest"
+)
 
-// Service is the structured representation of a Twilio Service.
+// Service is the structured representation of a Twilio Service. Limits, Notifications, Media and the
+// webhook retry counts are only returned by v2.
 type Service struct {
-	SID                          string      `json:"sid,omitempty"`
-	AccountSID                   string      `json:"account_sid,omitempty"`
-	FriendlyName                 string      `json:"friendly_name,omitempty"`
-	DateCreated                  *time.Time  `json:"date_created,omitempty"`
-	DateUpdated                  *time.Time  `json:"date_updated,omitempty"`
-	DefaultServiceRoleSID        string      `json:"default_service_role_sid,omitempty"`
-	DefaultChannelRoleSID        string      `json:"default_channel_role_sid,omitempty"`
-	DefaultChannelCreatorRoleSID string      `json:"default_channel_creator_rolesid,omitempty"`
-	TypingIndicatorTimeout       int         `json:"typing_indicator_timeout,omitempty"`
-	ReadStatusEnabled            bool        `json:"read_status_enabled,omitempty"`
-	ConsumptionReportInterval    int         `json:"consumption_report_interval,omitempty"`
-	ReachabilityEnabled          bool        `json:"reachability_enabled,omitempty"`
-	PreWebhookURL                string      `json:"pre_webhook_url,omitempty"`
-	PostWebhookURL               string      `json:"post_webhook_url,omitempty"`
-	WebhookMethod                string      `json:"webhook_method,omitempty"`
-	WebhookFilters               string      `json:"webhook_filters,omitempty"`
-	URL                          string      `json:"url,omitempty"`
-	Links                        ServiceLink `json:"links,omitempty"`
+	SID                          string                `json:"sid,omitempty"`
+	AccountSID                   string                `json:"account_sid,omitempty"`
+	FriendlyName                 string                `json:"friendly_name,omitempty"`
+	DateCreated                  *time.Time            `json:"date_created,omitempty"`
+	DateUpdated                  *time.Time            `json:"date_updated,omitempty"`
+	DefaultServiceRoleSID        string                `json:"default_service_role_sid,omitempty"`
+	DefaultChannelRoleSID        string                `json:"default_channel_role_sid,omitempty"`
+	DefaultChannelCreatorRoleSID string                `json:"default_channel_creator_role_sid,omitempty"`
+	TypingIndicatorTimeout       int                   `json:"typing_indicator_timeout,omitempty"`
+	ReadStatusEnabled            bool                  `json:"read_status_enabled,omitempty"`
+	ConsumptionReportInterval    int                   `json:"consumption_report_interval,omitempty"`
+	ReachabilityEnabled          bool                  `json:"reachability_enabled,omitempty"`
+	PreWebhookURL                string                `json:"pre_webhook_url,omitempty"`
+	PostWebhookURL               string                `json:"post_webhook_url,omitempty"`
+	WebhookMethod                string                `json:"webhook_method,omitempty"`
+	WebhookFilters               []string              `json:"webhook_filters,omitempty"`
+	PreWebhookRetryCount         int                   `json:"pre_webhook_retry_count,omitempty"`
+	PostWebhookRetryCount        int                   `json:"post_webhook_retry_count,omitempty"`
+	Limits                       *ServiceLimits        `json:"limits,omitempty"`
+	Notifications                *ServiceNotifications `json:"notifications,omitempty"`
+	Media                        *ServiceMedia         `json:"media,omitempty"`
+	URL                          string                `json:"url,omitempty"`
+	Links                        ServiceLink           `json:"links,omitempty"`
 }
 
 // ServiceLink is the structured representation of a Link within a Service.
@@ -37,11 +44,44 @@ type ServiceLink struct {
 	Channels string `json:"channels,omitempty"`
 	Roles    string `json:"roles,omitempty"`
 	Users    string `json:"users,omitempty"`
+	Bindings string `json:"bindings,omitempty"`
+}
+
+// ServiceLimits is the structured representation of the limits of a v2 Service.
+type ServiceLimits struct {
+	ChannelMembers int `json:"channel_members,omitempty"`
+	UserChannels   int `json:"user_channels,omitempty"`
+}
+
+// ServiceMedia is the structured representation of the media settings of a v2 Service.
+type ServiceMedia struct {
+	SizeLimitMB          int    `json:"size_limit_mb,omitempty"`
+	CompatibilityMessage string `json:"compatibility_message,omitempty"`
+}
+
+// ServiceNotifications is the structured representation of the push notification settings of a v2
+// Service.
+type ServiceNotifications struct {
+	LogEnabled         bool                `json:"log_enabled,omitempty"`
+	NewMessage         *NotificationConfig `json:"new_message,omitempty"`
+	AddedToChannel     *NotificationConfig `json:"added_to_channel,omitempty"`
+	RemovedFromChannel *NotificationConfig `json:"removed_from_channel,omitempty"`
+	InvitedToChannel   *NotificationConfig `json:"invited_to_channel,omitempty"`
+}
+
+// NotificationConfig is the structured representation of the settings for one kind of push
+// notification.
+type NotificationConfig struct {
+	Enabled           bool   `json:"enabled,omitempty"`
+	Template          string `json:"template,omitempty"`
+	Sound             string `json:"sound,omitempty"`
+	BadgeCountEnabled bool   `json:"badge_count_enabled,omitempty"`
 }
 
 // ServicesResponse is the structured representation of the response Twilio issues when asking for a list of Services.
 type ServicesResponse struct {
 	Services []Service `json:"services,omitempty"`
+	Meta     Meta      `json:"meta"`
 }
 
 // NewService returns a Service with the required fields.
@@ -52,10 +92,20 @@ func NewService(friendlyName string) Service {
 }
 
 // Service retrieves a specific Service from Twilio given its SID.
-func (c *Client) Service(sid string) (Service, error) {
+func (c *Client) Service(ctx context.Context, sid string) (Service, error) {
 	var service Service
 
-	data, err := c.getService(fmt.Sprintf("Services/%s", sid), nil)
+	if err := rest.ValidateSID(rest.PrefixService, sid); err != nil {
+		return service, err
+	}
+
+	path, err := rest.Path(sid)
+
+	if err != nil {
+		return service, err
+	}
+
+	data, err := c.getService(ctx, path, nil)
 
 	if err != nil {
 		return service, err
@@ -68,31 +118,26 @@ func (c *Client) Service(sid string) (Service, error) {
 	return service, nil
 }
 
-// Services retrieves a list of all Services from Twilio.
-func (c *Client) Services() ([]Service, error) {
-	var serviceRes ServicesResponse
-
-	data, err := c.getService("", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = json.Unmarshal(data, &serviceRes); err != nil {
-		return nil, err
-	}
+// Services retrieves a list of all Services from Twilio, following every page of results unless a
+// Limit is given.
+func (c *Client) Services(ctx context.Context, opts ...ListOption) ([]Service, error) {
+	return listAll[Service](ctx, c, c.serviceURL(""), opts)
+}
 
-	return serviceRes.Services, nil
+// IterServices lazily iterates over all Services in the account, fetching pages on demand.
+func (c *Client) IterServices(ctx context.Context, opts ...ListOption) iter.Seq2[Service, error] {
+	return iterate[Service](ctx, c, c.serviceURL(""), opts)
 }
 
 // CreateService creates a new Service within Twilio.
-func (c *Client) CreateService(service Service) (Service, error) {
+func (c *Client) CreateService(ctx context.Context, service Service) (Service, error) {
 	var createdService Service
 
 	forms := url.Values{}
 	forms.Add("FriendlyName", service.FriendlyName)
 	payload := []byte(forms.Encode())
 
-	data, err := c.postService("", payload, getFormHeader())
+	data, err := c.postService(ctx, "", payload, getFormHeader())
 
 	if err != nil {
 		return createdService, err
@@ -105,27 +150,82 @@ func (c *Client) CreateService(service Service) (Service, error) {
 	return createdService, nil
 }
 
-// TODO: See TODO for Service struct. Same problem.
+// ServiceUpdate describes changes to a Service. Only the fields that are set are sent to Twilio, so
+// e.g. renaming a Service doesn't reset its webhooks.
+type ServiceUpdate struct {
+	FriendlyName                 *string
+	DefaultServiceRoleSID        *string
+	DefaultChannelRoleSID        *string
+	DefaultChannelCreatorRoleSID *string
+	ReadStatusEnabled            *bool
+	ReachabilityEnabled          *bool
+	ConsumptionReportInterval    *int
+	TypingIndicatorTimeout       *int
+	PreWebhookURL                *string
+	PostWebhookURL               *string
+	WebhookMethod                *string
+	WebhookFilters               []string // Replaces the Service's filters when non-nil, an empty slice clears them.
+
+	// Only supported by v2.
+	PreWebhookRetryCount              *int
+	PostWebhookRetryCount             *int
+	LimitsChannelMembers              *int
+	LimitsUserChannels                *int
+	MediaCompatibilityMessage         *string
+	NotificationsLogEnabled           *bool
+	NotificationsNewMessageEnabled    *bool
+	NotificationsNewMessageTemplate   *string
+	NotificationsNewMessageSound      *string
+	NotificationsNewMessageBadgeCount *bool
+	NotificationsAddedToChannel       *bool
+	NotificationsRemovedFromChannel   *bool
+	NotificationsInvitedToChannel     *bool
+}
 
-// UpdateService updates an existing Service in Twilio.
-func (c *Client) UpdateService(service Service) (Service, error) {
+// UpdateService updates an existing Service in Twilio, leaving any field not set in the update as
+// it is.
+func (c *Client) UpdateService(ctx context.Context, sid string, update ServiceUpdate) (Service, error) {
 	var updatedService Service
 
+	if err := rest.ValidateSID(rest.PrefixService, sid); err != nil {
+		return updatedService, err
+	}
+
 	forms := url.Values{}
-	forms.Add("FriendlyName", service.FriendlyName)
-	forms.Add("DefaultChannelRoleSid", service.DefaultChannelRoleSID)
-	forms.Add("DefaultChannelCreatorRoleSid", service.DefaultChannelCreatorRoleSID)
-	forms.Add("ReadStatusEnabled", strconv.FormatBool(service.ReadStatusEnabled))
-	forms.Add("ReachabilityEnabled", strconv.FormatBool(service.ReachabilityEnabled))
-	forms.Add("ConsumptionReportInterval", strconv.Itoa(service.ConsumptionReportInterval))
-	forms.Add("TypingIndicatorTimeout", strconv.Itoa(service.TypingIndicatorTimeout))
-	forms.Add("PreWebhookUrl", service.PreWebhookURL)
-	forms.Add("PostWebhookUrl", service.PostWebhookURL)
-	forms.Add("WebhookMethod", service.WebhookMethod)
-	forms.Add("WebhookFilters", service.WebhookFilters)
+	rest.SetString(forms, "FriendlyName", update.FriendlyName)
+	rest.SetString(forms, "DefaultServiceRoleSid", update.DefaultServiceRoleSID)
+	rest.SetString(forms, "DefaultChannelRoleSid", update.DefaultChannelRoleSID)
+	rest.SetString(forms, "DefaultChannelCreatorRoleSid", update.DefaultChannelCreatorRoleSID)
+	rest.SetBool(forms, "ReadStatusEnabled", update.ReadStatusEnabled)
+	rest.SetBool(forms, "ReachabilityEnabled", update.ReachabilityEnabled)
+	rest.SetInt(forms, "ConsumptionReportInterval", update.ConsumptionReportInterval)
+	rest.SetInt(forms, "TypingIndicatorTimeout", update.TypingIndicatorTimeout)
+	rest.SetString(forms, "PreWebhookUrl", update.PreWebhookURL)
+	rest.SetString(forms, "PostWebhookUrl", update.PostWebhookURL)
+	rest.SetString(forms, "WebhookMethod", update.WebhookMethod)
+	rest.SetList(forms, "WebhookFilters", update.WebhookFilters)
+	rest.SetInt(forms, "PreWebhookRetryCount", update.PreWebhookRetryCount)
+	rest.SetInt(forms, "PostWebhookRetryCount", update.PostWebhookRetryCount)
+	rest.SetInt(forms, "Limits.ChannelMembers", update.LimitsChannelMembers)
+	rest.SetInt(forms, "Limits.UserChannels", update.LimitsUserChannels)
+	rest.SetString(forms, "Media.CompatibilityMessage", update.MediaCompatibilityMessage)
+	rest.SetBool(forms, "Notifications.LogEnabled", update.NotificationsLogEnabled)
+	rest.SetBool(forms, "Notifications.NewMessage.Enabled", update.NotificationsNewMessageEnabled)
+	rest.SetString(forms, "Notifications.NewMessage.Template", update.NotificationsNewMessageTemplate)
+	rest.SetString(forms, "Notifications.NewMessage.Sound", update.NotificationsNewMessageSound)
+	rest.SetBool(forms, "Notifications.NewMessage.BadgeCountEnabled", update.NotificationsNewMessageBadgeCount)
+	rest.SetBool(forms, "Notifications.AddedToChannel.Enabled", update.NotificationsAddedToChannel)
+	rest.SetBool(forms, "Notifications.RemovedFromChannel.Enabled", update.NotificationsRemovedFromChannel)
+	rest.SetBool(forms, "Notifications.InvitedToChannel.Enabled", update.NotificationsInvitedToChannel)
 	payload := []byte(forms.Encode())
 
-	data, err := c.postService(service.SID, payload, getFormHeader())
+	path, err := rest.Path(sid)
+
+	if err != nil {
+		return updatedService, err
+	}
+
+	data, err := c.postService(ctx, path, payload, getFormHeader())
 
 	if err != nil {
 		return updatedService, err
@@ -139,8 +239,18 @@ func (c *Client) UpdateService(service Service) (Service, error) {
 }
 
 // DeleteService deletes a Service from Twilio given its SID.
-func (c *Client) DeleteService(sid string) error {
-	data, err := c.deleteService(sid)
+func (c *Client) DeleteService(ctx context.Context, sid string) error {
+	if err := rest.ValidateSID(rest.PrefixService, sid); err != nil {
+		return err
+	}
+
+	path, err := rest.Path(sid)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteService(ctx, path)
 
 	if err != nil {
 		return err
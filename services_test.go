@@ -0,0 +1,43 @@
+package twiligo_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/twiligotest"
+)
+
+func TestUpdateServiceWebhookFilters(t *testing.T) {
+	ctx := context.Background()
+	srv := twiligotest.NewServer()
+	defer srv.Close()
+
+	service := srv.AddService("filters")
+	client := srv.Client(service.SID)
+
+	updated, err := client.UpdateService(ctx, service.SID, twiligo.ServiceUpdate{WebhookFilters: []string{"onMessageSent", "onChannelAdded"}})
+	if err != nil {
+		t.Fatalf("UpdateService: %v", err)
+	}
+	if want := []string{"onMessageSent", "onChannelAdded"}; !slices.Equal(updated.WebhookFilters, want) {
+		t.Fatalf("WebhookFilters = %v, want %v", updated.WebhookFilters, want)
+	}
+
+	updated, err = client.UpdateService(ctx, service.SID, twiligo.ServiceUpdate{FriendlyName: twiligo.String("renamed")})
+	if err != nil {
+		t.Fatalf("UpdateService: %v", err)
+	}
+	if len(updated.WebhookFilters) != 2 {
+		t.Fatalf("a nil WebhookFilters changed the filters to %v", updated.WebhookFilters)
+	}
+
+	updated, err = client.UpdateService(ctx, service.SID, twiligo.ServiceUpdate{WebhookFilters: []string{}})
+	if err != nil {
+		t.Fatalf("UpdateService: %v", err)
+	}
+	if len(updated.WebhookFilters) != 0 {
+		t.Fatalf("an empty WebhookFilters left the filters at %v", updated.WebhookFilters)
+	}
+}
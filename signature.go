@@ -0,0 +1,216 @@
+package twiligo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidSignature is returned when a webhook request doesn't carry a valid X-Twilio-Signature.
+var ErrInvalidSignature = errors.New("twiligo: invalid X-Twilio-Signature")
+
+// SignatureHeader is the header Twilio signs webhook requests with.
+const SignatureHeader = "X-Twilio-Signature"
+
+// ComputeSignature computes the signature Twilio sends with a webhook request: an HMAC-SHA1, keyed
+// with the auth token, over the full URL followed by every POST parameter sorted by name.
+func ComputeSignature(authToken, rawURL string, params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(rawURL)
+	for _, key := range keys {
+		values := append([]string(nil), params[key]...)
+		sort.Strings(values)
+
+		for _, value := range values {
+			b.WriteString(key)
+			b.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(b.String()))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateSignature reports whether the signature matches the given URL and POST parameters. The
+// URL is also checked with and without its default port because Twilio may sign either form.
+func ValidateSignature(authToken, rawURL string, params url.Values, signature string) bool {
+	if signature == "" {
+		return false
+	}
+
+	for _, candidate := range signatureURLs(rawURL) {
+		expected := ComputeSignature(authToken, candidate, params)
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signatureURLs returns the URL as given along with its variant with the default port toggled.
+func signatureURLs(rawURL string) []string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return []string{rawURL}
+	}
+
+	defaultPort := "443"
+	if u.Scheme == "http" {
+		defaultPort = "80"
+	}
+
+	alt := *u
+	if host, port, err := net.SplitHostPort(u.Host); err == nil {
+		if port != defaultPort {
+			return []string{rawURL}
+		}
+		alt.Host = host
+	} else {
+		alt.Host = net.JoinHostPort(u.Host, defaultPort)
+	}
+
+	return []string{rawURL, alt.String()}
+}
+
+// SignatureOption configures how the public URL of a webhook request is reconstructed.
+type SignatureOption func(*signatureOptions)
+
+type signatureOptions struct {
+	publicURL      *url.URL
+	trustForwarded bool
+	authToken      AuthProvider
+}
+
+// WithPublicURL sets the scheme and host Twilio uses to reach the webhook, for when a proxy or load
+// balancer rewrites them before the request reaches the handler.
+func WithPublicURL(base string) SignatureOption {
+	return func(o *signatureOptions) {
+		if u, err := url.Parse(base); err == nil {
+			o.publicURL = u
+		}
+	}
+}
+
+// TrustForwardedHeaders uses the X-Forwarded-Proto and X-Forwarded-Host headers set by a proxy to
+// reconstruct the URL Twilio signed. Only use this behind a proxy that overwrites those headers.
+func TrustForwardedHeaders() SignatureOption {
+	return func(o *signatureOptions) {
+		o.trustForwarded = true
+	}
+}
+
+// WithAuthToken validates signatures against the password of the given AuthProvider, read for every
+// request, for when the auth token is rotated while a Client authenticated with an API Key runs.
+func WithAuthToken(provider AuthProvider) SignatureOption {
+	return func(o *signatureOptions) {
+		o.authToken = provider
+	}
+}
+
+// requestURL reconstructs the URL Twilio sent the request to.
+func (o signatureOptions) requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+
+	if o.trustForwarded {
+		if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+			scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+		}
+
+		if fwdHost := r.Header.Get("X-Forwarded-Host"); fwdHost != "" {
+			host = strings.TrimSpace(strings.Split(fwdHost, ",")[0])
+		}
+	}
+
+	if o.publicURL != nil {
+		scheme = o.publicURL.Scheme
+		host = o.publicURL.Host
+	}
+
+	return scheme + "://" + host + r.URL.RequestURI()
+}
+
+// ValidateRequest checks the X-Twilio-Signature of an incoming webhook request against the account's
+// auth token, which Twilio signs webhooks with even when the Client uses an API Key. The token comes
+// from the Client's AuthProvider when it authenticates as the account, so a rotated token is picked
+// up, and otherwise from the token given to New unless WithAuthToken is set. The request's form is
+// parsed, so handlers can still read it through r.PostForm.
+func (c *Client) ValidateRequest(r *http.Request, opts ...SignatureOption) error {
+	var options signatureOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	token, err := c.signingToken(r.Context(), options)
+
+	if err != nil {
+		return err
+	}
+
+	if token == "" {
+		return errors.New("Validating webhook signatures requires the account's auth token")
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+
+	if !ValidateSignature(token, options.requestURL(r), r.PostForm, r.Header.Get(SignatureHeader)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// signingToken returns the auth token webhook signatures are validated against.
+func (c *Client) signingToken(ctx context.Context, options signatureOptions) (string, error) {
+	if options.authToken != nil {
+		_, token, err := options.authToken.Credentials(ctx)
+		return token, err
+	}
+
+	username, password, err := c.auth.Credentials(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	// Only a provider authenticating as the account itself holds the auth token.
+	if username == c.sid {
+		return password, nil
+	}
+
+	return c.token, nil
+}
+
+// RequireSignature wraps a webhook handler so that only requests carrying a valid X-Twilio-Signature
+// reach it. Anything else is rejected with a 403.
+func (c *Client) RequireSignature(next http.Handler, opts ...SignatureOption) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := c.ValidateRequest(r, opts...); err != nil {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,135 @@
+package twiligo_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+)
+
+// The example from Twilio's webhook security documentation.
+const (
+	twilioToken     = "12345"
+	twilioURL       = "https://mycompany.com/myapp.php?foo=1&bar=2"
+	twilioSignature = "0/KCTR6DLpKmkAf8muzZqo1nDgQ="
+)
+
+var twilioParams = url.Values{
+	"CallSid": {"CA1234567890ABCDE"},
+	"Caller":  {"+12349013030"},
+	"Digits":  {"1234"},
+	"From":    {"+12349013030"},
+	"To":      {"+18005551212"},
+}
+
+func TestComputeSignature(t *testing.T) {
+	if got := twiligo.ComputeSignature(twilioToken, twilioURL, twilioParams); got != twilioSignature {
+		t.Errorf("ComputeSignature = %s, want %s", got, twilioSignature)
+	}
+}
+
+func TestValidateSignature(t *testing.T) {
+	withPort := twiligo.ComputeSignature(twilioToken, "https://mycompany.com:443/myapp.php?foo=1&bar=2", twilioParams)
+
+	tests := []struct {
+		name      string
+		url       string
+		params    url.Values
+		signature string
+		want      bool
+	}{
+		{"twilio example", twilioURL, twilioParams, twilioSignature, true},
+		{"signed without port, received with it", "https://mycompany.com:443/myapp.php?foo=1&bar=2", twilioParams, twilioSignature, true},
+		{"signed with port, received without it", twilioURL, twilioParams, withPort, true},
+		{"other port", "https://mycompany.com:8443/myapp.php?foo=1&bar=2", twilioParams, twilioSignature, false},
+		{"other url", "https://mycompany.com/myapp.php?foo=1&bar=3", twilioParams, twilioSignature, false},
+		{"other params", twilioURL, url.Values{"Digits": {"1234"}}, twilioSignature, false},
+		{"other token", twilioURL, twilioParams, twiligo.ComputeSignature("54321", twilioURL, twilioParams), false},
+		{"no signature", twilioURL, twilioParams, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := twiligo.ValidateSignature(twilioToken, test.url, test.params, test.signature); got != test.want {
+				t.Errorf("ValidateSignature = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestComputeSignatureRepeatedKeys(t *testing.T) {
+	// Twilio signs every value of a repeated key, sorted, after the key.
+	params := url.Values{"To": {"+2", "+1"}, "Body": {"hi"}}
+	got := twiligo.ComputeSignature(twilioToken, twilioURL, params)
+
+	reordered := url.Values{"Body": {"hi"}, "To": {"+1", "+2"}}
+	if want := twiligo.ComputeSignature(twilioToken, twilioURL, reordered); got != want {
+		t.Errorf("signature depends on the order of repeated values: %s != %s", got, want)
+	}
+
+	if first := twiligo.ComputeSignature(twilioToken, twilioURL, url.Values{"To": {"+1"}, "Body": {"hi"}}); got == first {
+		t.Error("signature ignores every value but the first")
+	}
+}
+
+func TestRequireSignature(t *testing.T) {
+	client := twiligo.New("AC123", twilioToken)
+	handler := client.RequireSignature(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PostForm.Get("Digits")))
+	}), twiligo.WithPublicURL("https://mycompany.com"))
+
+	tests := []struct {
+		name      string
+		params    url.Values
+		signature string
+		want      int
+	}{
+		{"signed", twilioParams, twilioSignature, http.StatusOK},
+		{"tampered params", url.Values{"Digits": {"9999"}}, twilioSignature, http.StatusForbidden},
+		{"forged signature", twilioParams, twiligo.ComputeSignature("guess", twilioURL, twilioParams), http.StatusForbidden},
+		{"unsigned", twilioParams, "", http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/myapp.php?foo=1&bar=2", strings.NewReader(test.params.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if test.signature != "" {
+				req.Header.Set(twiligo.SignatureHeader, test.signature)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.want {
+				t.Fatalf("status %d, want %d", rec.Code, test.want)
+			}
+
+			if test.want == http.StatusOK && rec.Body.String() != "1234" {
+				t.Errorf("handler read %q from the form, want 1234", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestValidateRequestForwardedHeaders(t *testing.T) {
+	client := twiligo.New("AC123", twilioToken)
+
+	req := httptest.NewRequest(http.MethodPost, "http://internal:8080/myapp.php?foo=1&bar=2", strings.NewReader(twilioParams.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(twiligo.SignatureHeader, twilioSignature)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "mycompany.com")
+
+	if err := client.ValidateRequest(req); !errors.Is(err, twiligo.ErrInvalidSignature) {
+		t.Errorf("ValidateRequest trusted forwarded headers by default: %v", err)
+	}
+
+	if err := client.ValidateRequest(req, twiligo.TrustForwardedHeaders()); err != nil {
+		t.Errorf("ValidateRequest with TrustForwardedHeaders: %v", err)
+	}
+}
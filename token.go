@@ -0,0 +1,136 @@
+package twiligo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultTokenTTL is how long an AccessToken is valid for unless its TTL is set.
+const DefaultTokenTTL = time.Hour
+
+// maxTokenTTL is the longest lifetime Twilio accepts for an AccessToken.
+const maxTokenTTL = 24 * time.Hour
+
+// ChatGrant gives the holder of an AccessToken access to a Chat Service.
+type ChatGrant struct {
+	ServiceSID        string `json:"service_sid,omitempty"`
+	EndpointID        string `json:"endpoint_id,omitempty"`
+	PushCredentialSID string `json:"push_credential_sid,omitempty"`
+}
+
+// AccessToken builds the signed JWTs that Twilio's client SDKs use to connect. Tokens are signed
+// with an API Key, never the account's auth token.
+type AccessToken struct {
+	AccountSID   string
+	APIKeySID    string
+	APIKeySecret string
+	Identity     string
+	TTL          time.Duration
+	Chat         *ChatGrant
+}
+
+// NewAccessToken creates an AccessToken for the given identity with the default TTL.
+func NewAccessToken(accountSID, apiKeySID, apiKeySecret, identity string) *AccessToken {
+	return &AccessToken{
+		AccountSID:   accountSID,
+		APIKeySID:    apiKeySID,
+		APIKeySecret: apiKeySecret,
+		Identity:     identity,
+		TTL:          DefaultTokenTTL,
+	}
+}
+
+// NewAccessToken creates an AccessToken for the Client's account with a ChatGrant for the Client's
+// Service already attached.
+func (c *Client) NewAccessToken(apiKeySID, apiKeySecret, identity string) *AccessToken {
+	token := NewAccessToken(c.sid, apiKeySID, apiKeySecret, identity)
+	token.Chat = &ChatGrant{ServiceSID: c.serviceSID}
+
+	return token
+}
+
+// WithChatGrant attaches a ChatGrant to the AccessToken and returns it for chaining.
+func (t *AccessToken) WithChatGrant(grant ChatGrant) *AccessToken {
+	t.Chat = &grant
+	return t
+}
+
+// WithTTL sets how long the AccessToken is valid for and returns it for chaining.
+func (t *AccessToken) WithTTL(ttl time.Duration) *AccessToken {
+	t.TTL = ttl
+	return t
+}
+
+type tokenGrants struct {
+	Identity string     `json:"identity,omitempty"`
+	Chat     *ChatGrant `json:"chat,omitempty"`
+}
+
+type tokenClaims struct {
+	ID        string      `json:"jti"`
+	Issuer    string      `json:"iss"`
+	Subject   string      `json:"sub"`
+	IssuedAt  int64       `json:"iat"`
+	NotBefore int64       `json:"nbf"`
+	ExpiresAt int64       `json:"exp"`
+	Grants    tokenGrants `json:"grants"`
+}
+
+// ToJWT signs the AccessToken with HS256 and returns the encoded JWT.
+func (t *AccessToken) ToJWT() (string, error) {
+	if t.AccountSID == "" || t.APIKeySID == "" || t.APIKeySecret == "" {
+		return "", errors.New("An AccessToken requires an account SID, API Key SID and API Key secret")
+	}
+
+	ttl := t.TTL
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+
+	if ttl > maxTokenTTL {
+		return "", fmt.Errorf("AccessToken TTL of %s exceeds the maximum of %s", ttl, maxTokenTTL)
+	}
+
+	now := time.Now()
+	header := map[string]string{
+		"typ": "JWT",
+		"alg": "HS256",
+		"cty": "twilio-fpa;v=1",
+	}
+	claims := tokenClaims{
+		ID:        fmt.Sprintf("%s-%d", t.APIKeySID, now.Unix()),
+		Issuer:    t.APIKeySID,
+		Subject:   t.AccountSID,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+		Grants: tokenGrants{
+			Identity: t.Identity,
+			Chat:     t.Chat,
+		},
+	}
+
+	headerJSON, err := json.Marshal(header)
+
+	if err != nil {
+		return "", err
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	mac := hmac.New(sha256.New, []byte(t.APIKeySecret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
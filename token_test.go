@@ -0,0 +1,120 @@
+package twiligo_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eriktate/twiligo"
+)
+
+// decodeJWT checks the HS256 signature of a JWT against secret and decodes its header and claims.
+func decodeJWT(t *testing.T, jwt, secret string) (header map[string]string, claims map[string]any) {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if want := base64.RawURLEncoding.EncodeToString(mac.Sum(nil)); parts[2] != want {
+		t.Fatalf("signature %s, want %s", parts[2], want)
+	}
+
+	for i, v := range []any{&header, &claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			t.Fatalf("decoding part %d: %v", i, err)
+		}
+
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatalf("unmarshaling part %d: %v", i, err)
+		}
+	}
+
+	return header, claims
+}
+
+func TestAccessTokenToJWT(t *testing.T) {
+	client := twiligo.New("AC123", "token", twiligo.WithServiceSID("IS123"))
+	token := client.NewAccessToken("SK123", "secret", "alice").WithTTL(2 * time.Hour)
+	token.Chat.EndpointID = "alice-web"
+
+	start := time.Now().Unix()
+	jwt, err := token.ToJWT()
+	if err != nil {
+		t.Fatalf("ToJWT: %v", err)
+	}
+
+	header, claims := decodeJWT(t, jwt, "secret")
+
+	if header["alg"] != "HS256" || header["typ"] != "JWT" || header["cty"] != "twilio-fpa;v=1" {
+		t.Errorf("header %v", header)
+	}
+
+	if claims["iss"] != "SK123" || claims["sub"] != "AC123" {
+		t.Errorf("iss %v and sub %v, want SK123 and AC123", claims["iss"], claims["sub"])
+	}
+
+	iat, _ := claims["iat"].(float64)
+	exp, _ := claims["exp"].(float64)
+	if int64(iat) < start || int64(exp-iat) != int64((2*time.Hour).Seconds()) {
+		t.Errorf("iat %v and exp %v, want a 2h lifetime from now", iat, exp)
+	}
+
+	if jti, _ := claims["jti"].(string); !strings.HasPrefix(jti, "SK123-") {
+		t.Errorf("jti %q, want it prefixed with the API Key SID", jti)
+	}
+
+	grants, _ := json.Marshal(claims["grants"])
+	want := `{"chat":{"endpoint_id":"alice-web","service_sid":"IS123"},"identity":"alice"}`
+	if string(grants) != want {
+		t.Errorf("grants %s, want %s", grants, want)
+	}
+}
+
+func TestAccessTokenDefaultTTL(t *testing.T) {
+	jwt, err := twiligo.NewAccessToken("AC123", "SK123", "secret", "alice").WithTTL(0).ToJWT()
+	if err != nil {
+		t.Fatalf("ToJWT: %v", err)
+	}
+
+	_, claims := decodeJWT(t, jwt, "secret")
+	if lifetime := claims["exp"].(float64) - claims["iat"].(float64); lifetime != twiligo.DefaultTokenTTL.Seconds() {
+		t.Errorf("lifetime %vs, want %vs", lifetime, twiligo.DefaultTokenTTL.Seconds())
+	}
+
+	if _, ok := claims["grants"].(map[string]any)["chat"]; ok {
+		t.Error("token without a ChatGrant carries grants.chat")
+	}
+}
+
+func TestAccessTokenErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		token *twiligo.AccessToken
+	}{
+		{"ttl over 24h", twiligo.NewAccessToken("AC123", "SK123", "secret", "alice").WithTTL(24*time.Hour + time.Second)},
+		{"no account", twiligo.NewAccessToken("", "SK123", "secret", "alice")},
+		{"no api key", twiligo.NewAccessToken("AC123", "", "secret", "alice")},
+		{"no secret", twiligo.NewAccessToken("AC123", "SK123", "", "alice")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if jwt, err := test.token.ToJWT(); err == nil {
+				t.Errorf("ToJWT returned %s, want an error", jwt)
+			}
+		})
+	}
+
+	if _, err := twiligo.NewAccessToken("AC123", "SK123", "secret", "alice").WithTTL(24 * time.Hour).ToJWT(); err != nil {
+		t.Errorf("ToJWT with a 24h TTL: %v", err)
+	}
+}
@@ -2,154 +2,289 @@ package twiligo
 
 import (
 	"bytes"
-	"fmt"
-	"io/ioutil"
-	"log"
+	"context"
+	"errors"
+	"io"
 	"net/http"
-	"os"
+	"net/url"
+	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
 )
 
 // Client houses Twilio account information to be used in AuthN/AuthZ and provides all methods
-// for interacting with the Twilio REST API.
+// for interacting with the Twilio REST API. Every method takes a context.Context which is attached
+// to the outgoing request, so cancellation and deadlines are honored by the underlying http.Client.
 type Client struct {
 	baseURL    string
+	version    APIVersion // Applied to baseURL once all options have run.
 	http       *http.Client
 	serviceSID string
 	sid        string
 	token      string
-
-	logger *log.Logger
+	auth       AuthProvider
+	retry      RetryPolicy
+	userAgent  string
+	timeout    time.Duration
+
+	logger      Logger
+	logPayloads bool
+	unredacted  bool
 }
 
-// NewClient creates a new Client and returns its pointer.
-func NewClient(baseURL, sid, serviceSID, token string) *Client {
-	logger := log.New(os.Stderr, "", log.LstdFlags)
+// DefaultBaseURL is the Twilio Chat API a Client talks to unless WithBaseURL is given.
+const DefaultBaseURL = "https://chat.twilio.com/v2"
+
+// DefaultUserAgent is sent with every request unless WithUserAgent is given.
+const DefaultUserAgent = "twiligo"
+
+// Option configures optional behavior of a Client.
+type Option func(*Client)
+
+// New creates a new Client for the account identified by sid and authenticated with token. Without
+// options, it talks to DefaultBaseURL with http.DefaultClient and isn't bound to a Service. The
+// token may be left empty when WithAPIKey or WithAuthProvider is given.
+func New(sid, token string, opts ...Option) *Client {
+	client := &Client{
+		baseURL:   DefaultBaseURL,
+		sid:       sid,
+		token:     token,
+		auth:      BasicAuth(sid, token),
+		http:      http.DefaultClient,
+		logger:    nopLogger{},
+		userAgent: DefaultUserAgent,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
 
-	return &Client{
-		baseURL:    baseURL,
-		sid:        sid,
-		serviceSID: serviceSID,
-		token:      token,
-		http:       http.DefaultClient,
-		logger:     logger,
+	if client.version != "" {
+		client.baseURL = withVersion(client.baseURL, client.version)
 	}
+
+	return client
 }
 
-func (c *Client) postService(path string, payload []byte, headers map[string]string) ([]byte, error) {
-	url := fmt.Sprintf("%s/Services/%s", c.baseURL, path)
-	return c.post(url, payload, headers)
+// NewClient creates a new Client and returns its pointer. It's equivalent to calling New with
+// WithBaseURL and WithServiceSID.
+func NewClient(baseURL, sid, serviceSID, token string, opts ...Option) *Client {
+	opts = append([]Option{WithBaseURL(baseURL), WithServiceSID(serviceSID)}, opts...)
+	return New(sid, token, opts...)
 }
 
-func (c *Client) postResource(path string, payload []byte, headers map[string]string) ([]byte, error) {
-	url := fmt.Sprintf("%s/Services/%s/%s", c.baseURL, c.serviceSID, path)
-	return c.post(url, payload, headers)
+// ForService returns a Client scoped to another Service in the same account. The returned Client
+// shares the HTTP client, credentials, retry policy and logging of its parent and is cheap enough to
+// create per request, so one account Client can serve any number of Services concurrently.
+func (c *Client) ForService(serviceSID string) *Client {
+	scoped := *c
+	scoped.serviceSID = serviceSID
+
+	return &scoped
 }
 
-func (c *Client) post(url string, payload []byte, headers map[string]string) ([]byte, error) {
-	log.Println("Posting to URL: %s", url)
-	log.Println("POST body:\n%s", string(payload))
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+// ServiceSID returns the SID of the Service the Client is scoped to.
+func (c *Client) ServiceSID() string {
+	return c.serviceSID
+}
 
-	if err != nil {
-		return nil, err
+// serviceURL builds the URL for a path relative to the account's Services. An empty path refers to
+// the list of Services itself.
+func (c *Client) serviceURL(path string) string {
+	if path == "" {
+		return c.baseURL + "/Services"
 	}
 
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
+	return c.baseURL + "/Services/" + path
+}
 
-	req.SetBasicAuth(c.sid, c.token)
+// rootURL builds the URL for a path relative to the base URL, for resources that don't belong to a
+// Service.
+func (c *Client) rootURL(path string) string {
+	return c.baseURL + "/" + path
+}
 
-	res, err := c.http.Do(req)
+// resourceURL builds the URL for a path relative to the Client's Service, failing if the Client
+// isn't bound to a valid Service.
+func (c *Client) resourceURL(path string) (string, error) {
+	if c.serviceSID == "" {
+		return "", errors.New("Client isn't bound to a Service, use WithServiceSID or ForService")
+	}
 
-	if err != nil {
-		return nil, err
+	if err := rest.ValidateSID(rest.PrefixService, c.serviceSID); err != nil {
+		return "", err
 	}
 
-	data, err := ioutil.ReadAll(res.Body)
+	return c.serviceURL(c.serviceSID + "/" + path), nil
+}
+
+func (c *Client) postService(ctx context.Context, path string, payload []byte, headers map[string]string) ([]byte, error) {
+	return c.post(ctx, c.serviceURL(path), payload, headers)
+}
+
+func (c *Client) postResource(ctx context.Context, path string, payload []byte, headers map[string]string) ([]byte, error) {
+	url, err := c.resourceURL(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return nil, fmt.Errorf("Post failed: %s", string(data))
-	}
-
-	log.Printf("Returned POST data:\n%s", string(data))
-	return data, nil
+	return c.post(ctx, url, payload, headers)
 }
 
-func (c *Client) getService(path string, headers map[string]string) ([]byte, error) {
-	url := fmt.Sprintf("%s/Services/%s", c.baseURL, path)
-	return c.get(url, headers)
+func (c *Client) post(ctx context.Context, url string, payload []byte, headers map[string]string) ([]byte, error) {
+	return c.do(ctx, "POST", url, payload, headers)
 }
 
-func (c *Client) getResource(path string, headers map[string]string) ([]byte, error) {
-	url := fmt.Sprintf("%s/Services/%s/%s", c.baseURL, c.serviceSID, path)
-	return c.get(url, headers)
+func (c *Client) getService(ctx context.Context, path string, headers map[string]string) ([]byte, error) {
+	return c.get(ctx, c.serviceURL(path), headers)
 }
 
-func (c *Client) get(url string, headers map[string]string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func (c *Client) getResource(ctx context.Context, path string, headers map[string]string) ([]byte, error) {
+	url, err := c.resourceURL(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
+	return c.get(ctx, url, headers)
+}
 
-	req.SetBasicAuth(c.sid, c.token)
+func (c *Client) get(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	return c.do(ctx, "GET", url, nil, headers)
+}
 
-	res, err := c.http.Do(req)
+func (c *Client) deleteResource(ctx context.Context, path string) ([]byte, error) {
+	url, err := c.resourceURL(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := ioutil.ReadAll(res.Body)
+	return c.delete(ctx, url)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) deleteService(ctx context.Context, path string) ([]byte, error) {
+	return c.delete(ctx, c.serviceURL(path))
+}
 
-	log.Printf("Returned GET data:\n%s", string(data))
-	return data, nil
+func (c *Client) delete(ctx context.Context, url string) ([]byte, error) {
+	return c.do(ctx, "DELETE", url, nil, nil)
 }
 
-func (c *Client) deleteResource(path string) ([]byte, error) {
-	url := fmt.Sprintf("%s/Services/%s/%s", c.baseURL, c.serviceSID, path)
-	return c.delete(url)
+// Do sends a request to an absolute URL of any Twilio API and returns the response body. It shares
+// the Client's credentials, retry policy, timeout and logging, and non-2xx responses are returned as
+// an *APIError, which lets packages such as conversations build on the same transport. A nil form
+// sends no body.
+func (c *Client) Do(ctx context.Context, method, url string, form url.Values) ([]byte, error) {
+	if form == nil {
+		return c.do(ctx, method, url, nil, nil)
+	}
+
+	return c.do(ctx, method, url, []byte(form.Encode()), getFormHeader())
 }
 
-func (c *Client) deleteService(path string) ([]byte, error) {
-	url := fmt.Sprintf("%s/Services/%s", c.baseURL, path)
-	return c.delete(url)
+// do executes a request against Twilio and returns the response body, retrying according to the
+// Client's RetryPolicy. Any non-2xx response is converted into an *APIError.
+func (c *Client) do(ctx context.Context, method, url string, payload []byte, headers map[string]string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		data, err := c.roundTrip(ctx, method, url, payload, headers)
+
+		if err == nil {
+			return data, nil
+		}
+
+		if attempt >= c.retry.MaxAttempts || !c.retry.retryable(ctx, method, err) {
+			return nil, err
+		}
+
+		wait := c.retry.backoff(attempt, err)
+		c.logger.WarnContext(ctx, "retrying Twilio request", "method", method, "url", url, "attempt", attempt, "wait", wait, "error", err)
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(RetryEvent{
+				Attempt: attempt,
+				Method:  method,
+				URL:     url,
+				Err:     err,
+				Wait:    wait,
+			})
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
 }
 
-func (c *Client) delete(url string) ([]byte, error) {
-	req, err := http.NewRequest("DELETE", url, nil)
+// roundTrip executes a single request against Twilio and returns the response body.
+func (c *Client) roundTrip(ctx context.Context, method, url string, payload []byte, headers map[string]string) ([]byte, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req.SetBasicAuth(c.sid, c.token)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	username, password, err := c.auth.Credentials(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+	req.SetBasicAuth(username, password)
+
+	if c.logPayloads {
+		c.logger.DebugContext(ctx, "Twilio request", "method", method, "url", url, "headers", redactHeaders(req.Header), "payload", c.redactForm(payload))
+	} else {
+		c.logger.DebugContext(ctx, "Twilio request", "method", method, "url", url)
+	}
+
+	start := time.Now()
 	res, err := c.http.Do(req)
 
 	if err != nil {
+		c.logger.DebugContext(ctx, "Twilio request failed", "method", method, "url", url, "error", err)
 		return nil, err
 	}
+	defer res.Body.Close()
 
-	data, err := ioutil.ReadAll(res.Body)
+	data, err := io.ReadAll(res.Body)
 
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("Returned DELETE data:\n%s", string(data))
+	if c.logPayloads {
+		c.logger.DebugContext(ctx, "Twilio response", "method", method, "url", url, "status", res.StatusCode, "duration", time.Since(start), "payload", c.redactJSON(data))
+	} else {
+		c.logger.DebugContext(ctx, "Twilio response", "method", method, "url", url, "status", res.StatusCode, "duration", time.Since(start))
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		apiErr := newAPIError(res.StatusCode, data)
+		apiErr.RetryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+		return nil, apiErr
+	}
+
 	return data, nil
 }
 
@@ -0,0 +1,711 @@
+// Package twiligomock provides a test double for twiligo.ChatAPI. Each method delegates to an
+// optional function field and records its arguments, so tests can stub responses and assert on
+// the calls made:
+//
+//	mock := &twiligomock.ChatAPI{
+//		ChannelFunc: func(ctx context.Context, id string) (twiligo.Channel, error) {
+//			return twiligo.Channel{SID: "CH123"}, nil
+//		},
+//	}
+//
+// Methods without a function return zero values and ErrNotStubbed.
+package twiligomock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+
+	"github.com/eriktate/twiligo"
+)
+
+// ErrNotStubbed is returned by methods whose function field wasn't set.
+var ErrNotStubbed = errors.New("twiligomock: method not stubbed")
+
+// Call is a single recorded call to the mock.
+type Call struct {
+	Method string
+	Args   []any // The arguments after the context, with variadic options as a slice.
+}
+
+// ChatAPI is a mock implementation of twiligo.ChatAPI.
+type ChatAPI struct {
+	mu    sync.Mutex
+	calls []Call
+
+	ChannelFunc              func(context.Context, string) (twiligo.Channel, error)
+	ChannelsFunc             func(context.Context, ...twiligo.ListOption) ([]twiligo.Channel, error)
+	IterChannelsFunc         func(context.Context, ...twiligo.ListOption) iter.Seq2[twiligo.Channel, error]
+	CreateChannelFunc        func(context.Context, twiligo.Channel) (twiligo.Channel, error)
+	UpdateChannelFunc        func(context.Context, string, twiligo.ChannelUpdate) (twiligo.Channel, error)
+	DeleteChannelFunc        func(context.Context, string) error
+	MessageFunc              func(context.Context, string, string) (twiligo.Message, error)
+	MessagesFunc             func(context.Context, string, ...twiligo.ListOption) ([]twiligo.Message, error)
+	IterMessagesFunc         func(context.Context, string, ...twiligo.ListOption) iter.Seq2[twiligo.Message, error]
+	SendMessageFunc          func(context.Context, string, twiligo.Message) (twiligo.Message, error)
+	UpdateMessageFunc        func(context.Context, string, string, twiligo.MessageUpdate) (twiligo.Message, error)
+	DeleteMessageFunc        func(context.Context, string, string) error
+	MemberFunc               func(context.Context, string, string) (twiligo.Member, error)
+	MembersFunc              func(context.Context, string, ...twiligo.ListOption) ([]twiligo.Member, error)
+	IterMembersFunc          func(context.Context, string, ...twiligo.ListOption) iter.Seq2[twiligo.Member, error]
+	AddMemberFunc            func(context.Context, string, twiligo.Member) (twiligo.Member, error)
+	UpdateMemberFunc         func(context.Context, string, string, twiligo.MemberUpdate) (twiligo.Member, error)
+	RemoveMemberFunc         func(context.Context, string, string) error
+	UserFunc                 func(context.Context, string) (twiligo.User, error)
+	UsersFunc                func(context.Context, ...twiligo.ListOption) ([]twiligo.User, error)
+	IterUsersFunc            func(context.Context, ...twiligo.ListOption) iter.Seq2[twiligo.User, error]
+	CreateUserFunc           func(context.Context, twiligo.User) (twiligo.User, error)
+	UpdateUserFunc           func(context.Context, string, twiligo.UserUpdate) (twiligo.User, error)
+	DeleteUserFunc           func(context.Context, string) error
+	RoleFunc                 func(context.Context, string) (twiligo.Role, error)
+	RolesFunc                func(context.Context, ...twiligo.ListOption) ([]twiligo.Role, error)
+	IterRolesFunc            func(context.Context, ...twiligo.ListOption) iter.Seq2[twiligo.Role, error]
+	CreateRoleFunc           func(context.Context, twiligo.Role) (twiligo.Role, error)
+	UpdateRoleFunc           func(context.Context, twiligo.Role) (twiligo.Role, error)
+	DeleteRoleFunc           func(context.Context, string) error
+	ServiceFunc              func(context.Context, string) (twiligo.Service, error)
+	ServicesFunc             func(context.Context, ...twiligo.ListOption) ([]twiligo.Service, error)
+	IterServicesFunc         func(context.Context, ...twiligo.ListOption) iter.Seq2[twiligo.Service, error]
+	CreateServiceFunc        func(context.Context, twiligo.Service) (twiligo.Service, error)
+	UpdateServiceFunc        func(context.Context, string, twiligo.ServiceUpdate) (twiligo.Service, error)
+	DeleteServiceFunc        func(context.Context, string) error
+	ChannelWebhookFunc       func(context.Context, string, string) (twiligo.ChannelWebhook, error)
+	ChannelWebhooksFunc      func(context.Context, string, ...twiligo.ListOption) ([]twiligo.ChannelWebhook, error)
+	IterChannelWebhooksFunc  func(context.Context, string, ...twiligo.ListOption) iter.Seq2[twiligo.ChannelWebhook, error]
+	CreateChannelWebhookFunc func(context.Context, string, twiligo.ChannelWebhook) (twiligo.ChannelWebhook, error)
+	UpdateChannelWebhookFunc func(context.Context, string, string, twiligo.ChannelWebhookUpdate) (twiligo.ChannelWebhook, error)
+	DeleteChannelWebhookFunc func(context.Context, string, string) error
+	UserBindingFunc          func(context.Context, string, string) (twiligo.Binding, error)
+	UserBindingsFunc         func(context.Context, string, ...twiligo.ListOption) ([]twiligo.Binding, error)
+	IterUserBindingsFunc     func(context.Context, string, ...twiligo.ListOption) iter.Seq2[twiligo.Binding, error]
+	DeleteUserBindingFunc    func(context.Context, string, string) error
+	CredentialFunc           func(context.Context, string) (twiligo.Credential, error)
+	CredentialsFunc          func(context.Context, ...twiligo.ListOption) ([]twiligo.Credential, error)
+	IterCredentialsFunc      func(context.Context, ...twiligo.ListOption) iter.Seq2[twiligo.Credential, error]
+	CreateCredentialFunc     func(context.Context, twiligo.Credential, twiligo.CredentialSecrets) (twiligo.Credential, error)
+	UpdateCredentialFunc     func(context.Context, string, twiligo.CredentialUpdate) (twiligo.Credential, error)
+	DeleteCredentialFunc     func(context.Context, string) error
+}
+
+var _ twiligo.ChatAPI = (*ChatAPI)(nil)
+
+func (m *ChatAPI) record(method string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns every call made to the mock, in order.
+func (m *ChatAPI) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the calls made to the named method, in order.
+func (m *ChatAPI) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets every recorded call.
+func (m *ChatAPI) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotStubbed, method)
+}
+
+// notStubbedSeq is returned by iterator methods without a function.
+func notStubbedSeq[T any](method string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, notStubbed(method))
+	}
+}
+
+// Channel records the call and delegates to ChannelFunc.
+func (m *ChatAPI) Channel(ctx context.Context, id string) (twiligo.Channel, error) {
+	m.record("Channel", id)
+
+	if m.ChannelFunc != nil {
+		return m.ChannelFunc(ctx, id)
+	}
+
+	return twiligo.Channel{}, notStubbed("Channel")
+}
+
+// Channels records the call and delegates to ChannelsFunc.
+func (m *ChatAPI) Channels(ctx context.Context, opts ...twiligo.ListOption) ([]twiligo.Channel, error) {
+	m.record("Channels", opts)
+
+	if m.ChannelsFunc != nil {
+		return m.ChannelsFunc(ctx, opts...)
+	}
+
+	return nil, notStubbed("Channels")
+}
+
+// IterChannels records the call and delegates to IterChannelsFunc.
+func (m *ChatAPI) IterChannels(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[twiligo.Channel, error] {
+	m.record("IterChannels", opts)
+
+	if m.IterChannelsFunc != nil {
+		return m.IterChannelsFunc(ctx, opts...)
+	}
+
+	return notStubbedSeq[twiligo.Channel]("IterChannels")
+}
+
+// CreateChannel records the call and delegates to CreateChannelFunc.
+func (m *ChatAPI) CreateChannel(ctx context.Context, channel twiligo.Channel) (twiligo.Channel, error) {
+	m.record("CreateChannel", channel)
+
+	if m.CreateChannelFunc != nil {
+		return m.CreateChannelFunc(ctx, channel)
+	}
+
+	return twiligo.Channel{}, notStubbed("CreateChannel")
+}
+
+// UpdateChannel records the call and delegates to UpdateChannelFunc.
+func (m *ChatAPI) UpdateChannel(ctx context.Context, sid string, update twiligo.ChannelUpdate) (twiligo.Channel, error) {
+	m.record("UpdateChannel", sid, update)
+
+	if m.UpdateChannelFunc != nil {
+		return m.UpdateChannelFunc(ctx, sid, update)
+	}
+
+	return twiligo.Channel{}, notStubbed("UpdateChannel")
+}
+
+// DeleteChannel records the call and delegates to DeleteChannelFunc.
+func (m *ChatAPI) DeleteChannel(ctx context.Context, sid string) error {
+	m.record("DeleteChannel", sid)
+
+	if m.DeleteChannelFunc != nil {
+		return m.DeleteChannelFunc(ctx, sid)
+	}
+
+	return notStubbed("DeleteChannel")
+}
+
+// Message records the call and delegates to MessageFunc.
+func (m *ChatAPI) Message(ctx context.Context, channelSID string, messageSID string) (twiligo.Message, error) {
+	m.record("Message", channelSID, messageSID)
+
+	if m.MessageFunc != nil {
+		return m.MessageFunc(ctx, channelSID, messageSID)
+	}
+
+	return twiligo.Message{}, notStubbed("Message")
+}
+
+// Messages records the call and delegates to MessagesFunc.
+func (m *ChatAPI) Messages(ctx context.Context, channelSID string, opts ...twiligo.ListOption) ([]twiligo.Message, error) {
+	m.record("Messages", channelSID, opts)
+
+	if m.MessagesFunc != nil {
+		return m.MessagesFunc(ctx, channelSID, opts...)
+	}
+
+	return nil, notStubbed("Messages")
+}
+
+// IterMessages records the call and delegates to IterMessagesFunc.
+func (m *ChatAPI) IterMessages(ctx context.Context, channelSID string, opts ...twiligo.ListOption) iter.Seq2[twiligo.Message, error] {
+	m.record("IterMessages", channelSID, opts)
+
+	if m.IterMessagesFunc != nil {
+		return m.IterMessagesFunc(ctx, channelSID, opts...)
+	}
+
+	return notStubbedSeq[twiligo.Message]("IterMessages")
+}
+
+// SendMessage records the call and delegates to SendMessageFunc.
+func (m *ChatAPI) SendMessage(ctx context.Context, channelSID string, message twiligo.Message) (twiligo.Message, error) {
+	m.record("SendMessage", channelSID, message)
+
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, channelSID, message)
+	}
+
+	return twiligo.Message{}, notStubbed("SendMessage")
+}
+
+// UpdateMessage records the call and delegates to UpdateMessageFunc.
+func (m *ChatAPI) UpdateMessage(ctx context.Context, channelSID string, messageSID string, update twiligo.MessageUpdate) (twiligo.Message, error) {
+	m.record("UpdateMessage", channelSID, messageSID, update)
+
+	if m.UpdateMessageFunc != nil {
+		return m.UpdateMessageFunc(ctx, channelSID, messageSID, update)
+	}
+
+	return twiligo.Message{}, notStubbed("UpdateMessage")
+}
+
+// DeleteMessage records the call and delegates to DeleteMessageFunc.
+func (m *ChatAPI) DeleteMessage(ctx context.Context, channelSID string, messageSID string) error {
+	m.record("DeleteMessage", channelSID, messageSID)
+
+	if m.DeleteMessageFunc != nil {
+		return m.DeleteMessageFunc(ctx, channelSID, messageSID)
+	}
+
+	return notStubbed("DeleteMessage")
+}
+
+// Member records the call and delegates to MemberFunc.
+func (m *ChatAPI) Member(ctx context.Context, channelSID string, memberSID string) (twiligo.Member, error) {
+	m.record("Member", channelSID, memberSID)
+
+	if m.MemberFunc != nil {
+		return m.MemberFunc(ctx, channelSID, memberSID)
+	}
+
+	return twiligo.Member{}, notStubbed("Member")
+}
+
+// Members records the call and delegates to MembersFunc.
+func (m *ChatAPI) Members(ctx context.Context, channelSID string, opts ...twiligo.ListOption) ([]twiligo.Member, error) {
+	m.record("Members", channelSID, opts)
+
+	if m.MembersFunc != nil {
+		return m.MembersFunc(ctx, channelSID, opts...)
+	}
+
+	return nil, notStubbed("Members")
+}
+
+// IterMembers records the call and delegates to IterMembersFunc.
+func (m *ChatAPI) IterMembers(ctx context.Context, channelSID string, opts ...twiligo.ListOption) iter.Seq2[twiligo.Member, error] {
+	m.record("IterMembers", channelSID, opts)
+
+	if m.IterMembersFunc != nil {
+		return m.IterMembersFunc(ctx, channelSID, opts...)
+	}
+
+	return notStubbedSeq[twiligo.Member]("IterMembers")
+}
+
+// AddMember records the call and delegates to AddMemberFunc.
+func (m *ChatAPI) AddMember(ctx context.Context, channelSID string, member twiligo.Member) (twiligo.Member, error) {
+	m.record("AddMember", channelSID, member)
+
+	if m.AddMemberFunc != nil {
+		return m.AddMemberFunc(ctx, channelSID, member)
+	}
+
+	return twiligo.Member{}, notStubbed("AddMember")
+}
+
+// UpdateMember records the call and delegates to UpdateMemberFunc.
+func (m *ChatAPI) UpdateMember(ctx context.Context, channelSID string, memberSID string, update twiligo.MemberUpdate) (twiligo.Member, error) {
+	m.record("UpdateMember", channelSID, memberSID, update)
+
+	if m.UpdateMemberFunc != nil {
+		return m.UpdateMemberFunc(ctx, channelSID, memberSID, update)
+	}
+
+	return twiligo.Member{}, notStubbed("UpdateMember")
+}
+
+// RemoveMember records the call and delegates to RemoveMemberFunc.
+func (m *ChatAPI) RemoveMember(ctx context.Context, channelSID string, memberSID string) error {
+	m.record("RemoveMember", channelSID, memberSID)
+
+	if m.RemoveMemberFunc != nil {
+		return m.RemoveMemberFunc(ctx, channelSID, memberSID)
+	}
+
+	return notStubbed("RemoveMember")
+}
+
+// User records the call and delegates to UserFunc.
+func (m *ChatAPI) User(ctx context.Context, identity string) (twiligo.User, error) {
+	m.record("User", identity)
+
+	if m.UserFunc != nil {
+		return m.UserFunc(ctx, identity)
+	}
+
+	return twiligo.User{}, notStubbed("User")
+}
+
+// Users records the call and delegates to UsersFunc.
+func (m *ChatAPI) Users(ctx context.Context, opts ...twiligo.ListOption) ([]twiligo.User, error) {
+	m.record("Users", opts)
+
+	if m.UsersFunc != nil {
+		return m.UsersFunc(ctx, opts...)
+	}
+
+	return nil, notStubbed("Users")
+}
+
+// IterUsers records the call and delegates to IterUsersFunc.
+func (m *ChatAPI) IterUsers(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[twiligo.User, error] {
+	m.record("IterUsers", opts)
+
+	if m.IterUsersFunc != nil {
+		return m.IterUsersFunc(ctx, opts...)
+	}
+
+	return notStubbedSeq[twiligo.User]("IterUsers")
+}
+
+// CreateUser records the call and delegates to CreateUserFunc.
+func (m *ChatAPI) CreateUser(ctx context.Context, user twiligo.User) (twiligo.User, error) {
+	m.record("CreateUser", user)
+
+	if m.CreateUserFunc != nil {
+		return m.CreateUserFunc(ctx, user)
+	}
+
+	return twiligo.User{}, notStubbed("CreateUser")
+}
+
+// UpdateUser records the call and delegates to UpdateUserFunc.
+func (m *ChatAPI) UpdateUser(ctx context.Context, sid string, update twiligo.UserUpdate) (twiligo.User, error) {
+	m.record("UpdateUser", sid, update)
+
+	if m.UpdateUserFunc != nil {
+		return m.UpdateUserFunc(ctx, sid, update)
+	}
+
+	return twiligo.User{}, notStubbed("UpdateUser")
+}
+
+// DeleteUser records the call and delegates to DeleteUserFunc.
+func (m *ChatAPI) DeleteUser(ctx context.Context, sid string) error {
+	m.record("DeleteUser", sid)
+
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(ctx, sid)
+	}
+
+	return notStubbed("DeleteUser")
+}
+
+// Role records the call and delegates to RoleFunc.
+func (m *ChatAPI) Role(ctx context.Context, sid string) (twiligo.Role, error) {
+	m.record("Role", sid)
+
+	if m.RoleFunc != nil {
+		return m.RoleFunc(ctx, sid)
+	}
+
+	return twiligo.Role{}, notStubbed("Role")
+}
+
+// Roles records the call and delegates to RolesFunc.
+func (m *ChatAPI) Roles(ctx context.Context, opts ...twiligo.ListOption) ([]twiligo.Role, error) {
+	m.record("Roles", opts)
+
+	if m.RolesFunc != nil {
+		return m.RolesFunc(ctx, opts...)
+	}
+
+	return nil, notStubbed("Roles")
+}
+
+// IterRoles records the call and delegates to IterRolesFunc.
+func (m *ChatAPI) IterRoles(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[twiligo.Role, error] {
+	m.record("IterRoles", opts)
+
+	if m.IterRolesFunc != nil {
+		return m.IterRolesFunc(ctx, opts...)
+	}
+
+	return notStubbedSeq[twiligo.Role]("IterRoles")
+}
+
+// CreateRole records the call and delegates to CreateRoleFunc.
+func (m *ChatAPI) CreateRole(ctx context.Context, role twiligo.Role) (twiligo.Role, error) {
+	m.record("CreateRole", role)
+
+	if m.CreateRoleFunc != nil {
+		return m.CreateRoleFunc(ctx, role)
+	}
+
+	return twiligo.Role{}, notStubbed("CreateRole")
+}
+
+// UpdateRole records the call and delegates to UpdateRoleFunc.
+func (m *ChatAPI) UpdateRole(ctx context.Context, role twiligo.Role) (twiligo.Role, error) {
+	m.record("UpdateRole", role)
+
+	if m.UpdateRoleFunc != nil {
+		return m.UpdateRoleFunc(ctx, role)
+	}
+
+	return twiligo.Role{}, notStubbed("UpdateRole")
+}
+
+// DeleteRole records the call and delegates to DeleteRoleFunc.
+func (m *ChatAPI) DeleteRole(ctx context.Context, sid string) error {
+	m.record("DeleteRole", sid)
+
+	if m.DeleteRoleFunc != nil {
+		return m.DeleteRoleFunc(ctx, sid)
+	}
+
+	return notStubbed("DeleteRole")
+}
+
+// Service records the call and delegates to ServiceFunc.
+func (m *ChatAPI) Service(ctx context.Context, sid string) (twiligo.Service, error) {
+	m.record("Service", sid)
+
+	if m.ServiceFunc != nil {
+		return m.ServiceFunc(ctx, sid)
+	}
+
+	return twiligo.Service{}, notStubbed("Service")
+}
+
+// Services records the call and delegates to ServicesFunc.
+func (m *ChatAPI) Services(ctx context.Context, opts ...twiligo.ListOption) ([]twiligo.Service, error) {
+	m.record("Services", opts)
+
+	if m.ServicesFunc != nil {
+		return m.ServicesFunc(ctx, opts...)
+	}
+
+	return nil, notStubbed("Services")
+}
+
+// IterServices records the call and delegates to IterServicesFunc.
+func (m *ChatAPI) IterServices(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[twiligo.Service, error] {
+	m.record("IterServices", opts)
+
+	if m.IterServicesFunc != nil {
+		return m.IterServicesFunc(ctx, opts...)
+	}
+
+	return notStubbedSeq[twiligo.Service]("IterServices")
+}
+
+// CreateService records the call and delegates to CreateServiceFunc.
+func (m *ChatAPI) CreateService(ctx context.Context, service twiligo.Service) (twiligo.Service, error) {
+	m.record("CreateService", service)
+
+	if m.CreateServiceFunc != nil {
+		return m.CreateServiceFunc(ctx, service)
+	}
+
+	return twiligo.Service{}, notStubbed("CreateService")
+}
+
+// UpdateService records the call and delegates to UpdateServiceFunc.
+func (m *ChatAPI) UpdateService(ctx context.Context, sid string, update twiligo.ServiceUpdate) (twiligo.Service, error) {
+	m.record("UpdateService", sid, update)
+
+	if m.UpdateServiceFunc != nil {
+		return m.UpdateServiceFunc(ctx, sid, update)
+	}
+
+	return twiligo.Service{}, notStubbed("UpdateService")
+}
+
+// DeleteService records the call and delegates to DeleteServiceFunc.
+func (m *ChatAPI) DeleteService(ctx context.Context, sid string) error {
+	m.record("DeleteService", sid)
+
+	if m.DeleteServiceFunc != nil {
+		return m.DeleteServiceFunc(ctx, sid)
+	}
+
+	return notStubbed("DeleteService")
+}
+
+// ChannelWebhook records the call and delegates to ChannelWebhookFunc.
+func (m *ChatAPI) ChannelWebhook(ctx context.Context, channelSID string, webhookSID string) (twiligo.ChannelWebhook, error) {
+	m.record("ChannelWebhook", channelSID, webhookSID)
+
+	if m.ChannelWebhookFunc != nil {
+		return m.ChannelWebhookFunc(ctx, channelSID, webhookSID)
+	}
+
+	return twiligo.ChannelWebhook{}, notStubbed("ChannelWebhook")
+}
+
+// ChannelWebhooks records the call and delegates to ChannelWebhooksFunc.
+func (m *ChatAPI) ChannelWebhooks(ctx context.Context, channelSID string, opts ...twiligo.ListOption) ([]twiligo.ChannelWebhook, error) {
+	m.record("ChannelWebhooks", channelSID, opts)
+
+	if m.ChannelWebhooksFunc != nil {
+		return m.ChannelWebhooksFunc(ctx, channelSID, opts...)
+	}
+
+	return nil, notStubbed("ChannelWebhooks")
+}
+
+// IterChannelWebhooks records the call and delegates to IterChannelWebhooksFunc.
+func (m *ChatAPI) IterChannelWebhooks(ctx context.Context, channelSID string, opts ...twiligo.ListOption) iter.Seq2[twiligo.ChannelWebhook, error] {
+	m.record("IterChannelWebhooks", channelSID, opts)
+
+	if m.IterChannelWebhooksFunc != nil {
+		return m.IterChannelWebhooksFunc(ctx, channelSID, opts...)
+	}
+
+	return notStubbedSeq[twiligo.ChannelWebhook]("IterChannelWebhooks")
+}
+
+// CreateChannelWebhook records the call and delegates to CreateChannelWebhookFunc.
+func (m *ChatAPI) CreateChannelWebhook(ctx context.Context, channelSID string, webhook twiligo.ChannelWebhook) (twiligo.ChannelWebhook, error) {
+	m.record("CreateChannelWebhook", channelSID, webhook)
+
+	if m.CreateChannelWebhookFunc != nil {
+		return m.CreateChannelWebhookFunc(ctx, channelSID, webhook)
+	}
+
+	return twiligo.ChannelWebhook{}, notStubbed("CreateChannelWebhook")
+}
+
+// UpdateChannelWebhook records the call and delegates to UpdateChannelWebhookFunc.
+func (m *ChatAPI) UpdateChannelWebhook(ctx context.Context, channelSID string, webhookSID string, update twiligo.ChannelWebhookUpdate) (twiligo.ChannelWebhook, error) {
+	m.record("UpdateChannelWebhook", channelSID, webhookSID, update)
+
+	if m.UpdateChannelWebhookFunc != nil {
+		return m.UpdateChannelWebhookFunc(ctx, channelSID, webhookSID, update)
+	}
+
+	return twiligo.ChannelWebhook{}, notStubbed("UpdateChannelWebhook")
+}
+
+// DeleteChannelWebhook records the call and delegates to DeleteChannelWebhookFunc.
+func (m *ChatAPI) DeleteChannelWebhook(ctx context.Context, channelSID string, webhookSID string) error {
+	m.record("DeleteChannelWebhook", channelSID, webhookSID)
+
+	if m.DeleteChannelWebhookFunc != nil {
+		return m.DeleteChannelWebhookFunc(ctx, channelSID, webhookSID)
+	}
+
+	return notStubbed("DeleteChannelWebhook")
+}
+
+// UserBinding records the call and delegates to UserBindingFunc.
+func (m *ChatAPI) UserBinding(ctx context.Context, identity string, bindingSID string) (twiligo.Binding, error) {
+	m.record("UserBinding", identity, bindingSID)
+
+	if m.UserBindingFunc != nil {
+		return m.UserBindingFunc(ctx, identity, bindingSID)
+	}
+
+	return twiligo.Binding{}, notStubbed("UserBinding")
+}
+
+// UserBindings records the call and delegates to UserBindingsFunc.
+func (m *ChatAPI) UserBindings(ctx context.Context, identity string, opts ...twiligo.ListOption) ([]twiligo.Binding, error) {
+	m.record("UserBindings", identity, opts)
+
+	if m.UserBindingsFunc != nil {
+		return m.UserBindingsFunc(ctx, identity, opts...)
+	}
+
+	return nil, notStubbed("UserBindings")
+}
+
+// IterUserBindings records the call and delegates to IterUserBindingsFunc.
+func (m *ChatAPI) IterUserBindings(ctx context.Context, identity string, opts ...twiligo.ListOption) iter.Seq2[twiligo.Binding, error] {
+	m.record("IterUserBindings", identity, opts)
+
+	if m.IterUserBindingsFunc != nil {
+		return m.IterUserBindingsFunc(ctx, identity, opts...)
+	}
+
+	return notStubbedSeq[twiligo.Binding]("IterUserBindings")
+}
+
+// DeleteUserBinding records the call and delegates to DeleteUserBindingFunc.
+func (m *ChatAPI) DeleteUserBinding(ctx context.Context, identity string, bindingSID string) error {
+	m.record("DeleteUserBinding", identity, bindingSID)
+
+	if m.DeleteUserBindingFunc != nil {
+		return m.DeleteUserBindingFunc(ctx, identity, bindingSID)
+	}
+
+	return notStubbed("DeleteUserBinding")
+}
+
+// Credential records the call and delegates to CredentialFunc.
+func (m *ChatAPI) Credential(ctx context.Context, sid string) (twiligo.Credential, error) {
+	m.record("Credential", sid)
+
+	if m.CredentialFunc != nil {
+		return m.CredentialFunc(ctx, sid)
+	}
+
+	return twiligo.Credential{}, notStubbed("Credential")
+}
+
+// Credentials records the call and delegates to CredentialsFunc.
+func (m *ChatAPI) Credentials(ctx context.Context, opts ...twiligo.ListOption) ([]twiligo.Credential, error) {
+	m.record("Credentials", opts)
+
+	if m.CredentialsFunc != nil {
+		return m.CredentialsFunc(ctx, opts...)
+	}
+
+	return nil, notStubbed("Credentials")
+}
+
+// IterCredentials records the call and delegates to IterCredentialsFunc.
+func (m *ChatAPI) IterCredentials(ctx context.Context, opts ...twiligo.ListOption) iter.Seq2[twiligo.Credential, error] {
+	m.record("IterCredentials", opts)
+
+	if m.IterCredentialsFunc != nil {
+		return m.IterCredentialsFunc(ctx, opts...)
+	}
+
+	return notStubbedSeq[twiligo.Credential]("IterCredentials")
+}
+
+// CreateCredential records the call and delegates to CreateCredentialFunc.
+func (m *ChatAPI) CreateCredential(ctx context.Context, credential twiligo.Credential, secrets twiligo.CredentialSecrets) (twiligo.Credential, error) {
+	m.record("CreateCredential", credential, secrets)
+
+	if m.CreateCredentialFunc != nil {
+		return m.CreateCredentialFunc(ctx, credential, secrets)
+	}
+
+	return twiligo.Credential{}, notStubbed("CreateCredential")
+}
+
+// UpdateCredential records the call and delegates to UpdateCredentialFunc.
+func (m *ChatAPI) UpdateCredential(ctx context.Context, sid string, update twiligo.CredentialUpdate) (twiligo.Credential, error) {
+	m.record("UpdateCredential", sid, update)
+
+	if m.UpdateCredentialFunc != nil {
+		return m.UpdateCredentialFunc(ctx, sid, update)
+	}
+
+	return twiligo.Credential{}, notStubbed("UpdateCredential")
+}
+
+// DeleteCredential records the call and delegates to DeleteCredentialFunc.
+func (m *ChatAPI) DeleteCredential(ctx context.Context, sid string) error {
+	m.record("DeleteCredential", sid)
+
+	if m.DeleteCredentialFunc != nil {
+		return m.DeleteCredentialFunc(ctx, sid)
+	}
+
+	return notStubbed("DeleteCredential")
+}
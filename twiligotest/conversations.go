@@ -0,0 +1,444 @@
+package twiligotest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/eriktate/twiligo"
+	"github.com/eriktate/twiligo/conversations"
+)
+
+// conversation holds the state of a single Conversation and everything within it.
+type conversation struct {
+	conversations.Conversation
+
+	participants []*conversations.Participant
+	messages     []*conversations.Message
+	nextIndex    int
+}
+
+// ConversationsClient returns a conversations.Client authenticated against the Server and scoped to
+// the given Service. Like Twilio, the Server shares Services and Users between Chat and
+// Conversations.
+func (s *Server) ConversationsClient(serviceSID string, opts ...twiligo.Option) *conversations.Client {
+	api := twiligo.New(s.AccountSID, s.AuthToken, opts...)
+	return conversations.New(api, conversations.WithBaseURL(s.URL), conversations.WithServiceSID(serviceSID))
+}
+
+func (svc *service) findConversation(id string) *conversation {
+	for _, conv := range svc.conversations {
+		if conv.SID == id || (conv.UniqueName != "" && conv.UniqueName == id) {
+			return conv
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) dispatchConversation(w http.ResponseWriter, r *request, svc *service, seg []string) *errorResponse {
+	if len(seg) == 0 {
+		return s.handleConversations(w, r, svc)
+	}
+
+	conv := svc.findConversation(seg[0])
+	if conv == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch {
+	case len(seg) == 1:
+		return s.handleConversation(w, r, svc, conv)
+	case seg[1] == "Participants" && len(seg) == 2:
+		return s.handleParticipants(w, r, svc, conv)
+	case seg[1] == "Participants" && len(seg) == 3:
+		return s.handleParticipant(w, r, conv, seg[2])
+	case seg[1] == "Messages" && len(seg) == 2:
+		return s.handleConversationMessages(w, r, conv)
+	case seg[1] == "Messages" && len(seg) == 3:
+		return s.handleConversationMessage(w, r, conv, seg[2])
+	}
+
+	return notFound(r.URL.Path)
+}
+
+// timeParam parses an ISO 8601 timestamp parameter, which Conversations accepts for imported
+// history.
+func timeParam(r *request, name string, field **time.Time) *errorResponse {
+	if !r.has(name) {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, r.form.Get(name))
+	if err != nil {
+		return invalidParam(name, r.form.Get(name))
+	}
+
+	t = t.UTC()
+	*field = &t
+	return nil
+}
+
+// timestamps applies the DateCreated and DateUpdated parameters of a request.
+func timestamps(r *request, created, updated **time.Time) *errorResponse {
+	if err := timeParam(r, "DateCreated", created); err != nil {
+		return err
+	}
+
+	return timeParam(r, "DateUpdated", updated)
+}
+
+// Conversations
+
+func (s *Server) handleConversations(w http.ResponseWriter, r *request, svc *service) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]conversations.Conversation, len(svc.conversations))
+		for i, conv := range svc.conversations {
+			items[i] = conv.Conversation
+		}
+
+		page(s, w, r, "conversations", items)
+	case http.MethodPost:
+		uniqueName := r.form.Get("UniqueName")
+		if uniqueName != "" && svc.findConversation(uniqueName) != nil {
+			return conflict(50353, "Conversation with provided unique name already exists")
+		}
+
+		state := conversations.State(r.form.Get("State"))
+		switch state {
+		case "":
+			state = conversations.StateActive
+		case conversations.StateActive, conversations.StateInactive, conversations.StateClosed:
+		default:
+			return invalidParam("State", string(state))
+		}
+
+		attrs, err := attributesParam(r, "")
+		if err != nil {
+			return err
+		}
+
+		sid := NewSID("CH")
+		conv := &conversation{
+			Conversation: conversations.Conversation{
+				SID:                 sid,
+				AccountSID:          s.AccountSID,
+				ChatServiceSID:      svc.SID,
+				MessagingServiceSID: r.form.Get("MessagingServiceSid"),
+				FriendlyName:        r.form.Get("FriendlyName"),
+				UniqueName:          uniqueName,
+				Attributes:          attrs,
+				State:               state,
+				DateCreated:         now(),
+				DateUpdated:         now(),
+				URL:                 s.resourceURL("Services", svc.SID, "Conversations", sid),
+				Links: conversations.ConversationLinks{
+					Participants: s.resourceURL("Services", svc.SID, "Conversations", sid, "Participants"),
+					Messages:     s.resourceURL("Services", svc.SID, "Conversations", sid, "Messages"),
+				},
+			},
+		}
+
+		if err := timestamps(r, &conv.DateCreated, &conv.DateUpdated); err != nil {
+			return err
+		}
+
+		svc.conversations = append(svc.conversations, conv)
+		writeJSON(w, http.StatusCreated, conv.Conversation)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleConversation(w http.ResponseWriter, r *request, svc *service, conv *conversation) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, conv.Conversation)
+	case http.MethodPost:
+		if r.has("UniqueName") {
+			uniqueName := r.form.Get("UniqueName")
+			if other := svc.findConversation(uniqueName); uniqueName != "" && other != nil && other != conv {
+				return conflict(50353, "Conversation with provided unique name already exists")
+			}
+			conv.UniqueName = uniqueName
+		}
+
+		attrs, err := attributesParam(r, conv.Attributes)
+		if err != nil {
+			return err
+		}
+
+		if r.has("State") {
+			state := conversations.State(r.form.Get("State"))
+			if state != conversations.StateActive && state != conversations.StateInactive && state != conversations.StateClosed {
+				return invalidParam("State", string(state))
+			}
+			conv.State = state
+		}
+
+		conv.Attributes = attrs
+		updateString(r, "FriendlyName", &conv.FriendlyName)
+		updateString(r, "MessagingServiceSid", &conv.MessagingServiceSID)
+		conv.DateUpdated = now()
+		if err := timestamps(r, &conv.DateCreated, &conv.DateUpdated); err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusOK, conv.Conversation)
+	case http.MethodDelete:
+		svc.conversations = slices.DeleteFunc(svc.conversations, func(other *conversation) bool { return other == conv })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Participants
+
+func (conv *conversation) findParticipant(sid string) *conversations.Participant {
+	for _, participant := range conv.participants {
+		if participant.SID == sid {
+			return participant
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleParticipants(w http.ResponseWriter, r *request, svc *service, conv *conversation) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]conversations.Participant, len(conv.participants))
+		for i, participant := range conv.participants {
+			items[i] = *participant
+		}
+
+		page(s, w, r, "participants", items)
+	case http.MethodPost:
+		identity := r.form.Get("Identity")
+		address := r.form.Get("MessagingBinding.Address")
+		if identity == "" && address == "" {
+			return missingParam("Identity")
+		}
+
+		for _, participant := range conv.participants {
+			if identity != "" && participant.Identity == identity {
+				return conflict(50433, "Participant already exists")
+			}
+		}
+
+		roleSID := r.form.Get("RoleSid")
+		if roleSID == "" {
+			roleSID = svc.DefaultChannelRoleSID
+		} else if svc.findRole(roleSID) == nil {
+			return invalidParam("RoleSid", roleSID)
+		}
+
+		attrs, err := attributesParam(r, "")
+		if err != nil {
+			return err
+		}
+
+		// Like Twilio, chat Participants are backed by a User, which is created when needed.
+		if identity != "" && svc.findUser(identity) == nil {
+			s.addUser(svc, identity, "", "{}", "")
+		}
+
+		sid := NewSID("MB")
+		participant := &conversations.Participant{
+			SID:             sid,
+			AccountSID:      s.AccountSID,
+			ChatServiceSID:  svc.SID,
+			ConversationSID: conv.SID,
+			Identity:        identity,
+			Attributes:      attrs,
+			RoleSID:         roleSID,
+			DateCreated:     now(),
+			DateUpdated:     now(),
+			URL:             s.resourceURL("Services", svc.SID, "Conversations", conv.SID, "Participants", sid),
+		}
+
+		if address != "" {
+			participant.MessagingBinding = &conversations.MessagingBinding{
+				Type:         "sms",
+				Address:      address,
+				ProxyAddress: r.form.Get("MessagingBinding.ProxyAddress"),
+			}
+		}
+
+		if err := timestamps(r, &participant.DateCreated, &participant.DateUpdated); err != nil {
+			return err
+		}
+
+		conv.participants = append(conv.participants, participant)
+		writeJSON(w, http.StatusCreated, participant)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleParticipant(w http.ResponseWriter, r *request, conv *conversation, sid string) *errorResponse {
+	participant := conv.findParticipant(sid)
+	if participant == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, participant)
+	case http.MethodPost:
+		attrs, err := attributesParam(r, participant.Attributes)
+		if err != nil {
+			return err
+		}
+
+		participant.Attributes = attrs
+		updateString(r, "RoleSid", &participant.RoleSID)
+
+		if r.has("LastReadMessageIndex") {
+			var index int
+			if err := updateInt(r, "LastReadMessageIndex", &index); err != nil {
+				return err
+			}
+
+			participant.LastReadMessageIndex = &index
+			participant.LastReadTimestamp = now().Format(time.RFC3339)
+		}
+
+		participant.DateUpdated = now()
+		if err := timestamps(r, &participant.DateCreated, &participant.DateUpdated); err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusOK, participant)
+	case http.MethodDelete:
+		conv.participants = slices.DeleteFunc(conv.participants, func(other *conversations.Participant) bool { return other == participant })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Messages
+
+func (conv *conversation) findMessage(sid string) *conversations.Message {
+	for _, msg := range conv.messages {
+		if msg.SID == sid {
+			return msg
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleConversationMessages(w http.ResponseWriter, r *request, conv *conversation) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]conversations.Message, len(conv.messages))
+		for i, msg := range conv.messages {
+			items[i] = *msg
+		}
+
+		if r.URL.Query().Get("Order") == "desc" {
+			slices.Reverse(items)
+		}
+
+		page(s, w, r, "messages", items)
+	case http.MethodPost:
+		if !r.has("Body") && !r.has("MediaSid") {
+			return missingParam("Body")
+		}
+
+		attrs, err := attributesParam(r, "")
+		if err != nil {
+			return err
+		}
+
+		author := r.form.Get("Author")
+		if author == "" {
+			author = "system"
+		}
+
+		var participantSID string
+		for _, participant := range conv.participants {
+			if participant.Identity == author {
+				participantSID = participant.SID
+			}
+		}
+
+		sid := NewSID("IM")
+		msg := &conversations.Message{
+			SID:             sid,
+			AccountSID:      s.AccountSID,
+			ChatServiceSID:  conv.ChatServiceSID,
+			ConversationSID: conv.SID,
+			Index:           conv.nextIndex,
+			Author:          author,
+			Body:            r.form.Get("Body"),
+			Attributes:      attrs,
+			ParticipantSID:  participantSID,
+			DateCreated:     now(),
+			DateUpdated:     now(),
+			URL:             s.resourceURL("Services", conv.ChatServiceSID, "Conversations", conv.SID, "Messages", sid),
+		}
+
+		if r.has("MediaSid") {
+			msg.Media = []conversations.Media{{SID: r.form.Get("MediaSid")}}
+		}
+
+		if err := timestamps(r, &msg.DateCreated, &msg.DateUpdated); err != nil {
+			return err
+		}
+
+		conv.nextIndex++
+		conv.messages = append(conv.messages, msg)
+		writeJSON(w, http.StatusCreated, msg)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleConversationMessage(w http.ResponseWriter, r *request, conv *conversation, sid string) *errorResponse {
+	msg := conv.findMessage(sid)
+	if msg == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, msg)
+	case http.MethodPost:
+		attrs, err := attributesParam(r, msg.Attributes)
+		if err != nil {
+			return err
+		}
+
+		msg.Attributes = attrs
+		updateString(r, "Author", &msg.Author)
+		updateString(r, "Body", &msg.Body)
+		msg.DateUpdated = now()
+		if err := timestamps(r, &msg.DateCreated, &msg.DateUpdated); err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusOK, msg)
+	case http.MethodDelete:
+		conv.messages = slices.DeleteFunc(conv.messages, func(other *conversations.Message) bool { return other == msg })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
@@ -0,0 +1,1121 @@
+package twiligotest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/eriktate/twiligo"
+)
+
+func (s *Server) route(w http.ResponseWriter, r *request) {
+	if err := s.dispatch(w, r); err != nil {
+		writeError(w, err)
+	}
+}
+
+func (s *Server) dispatch(w http.ResponseWriter, r *request) *errorResponse {
+	seg := r.segments
+	if len(seg) > 0 && seg[0] == "Credentials" {
+		switch len(seg) {
+		case 1:
+			return s.handleCredentials(w, r)
+		case 2:
+			return s.handleCredential(w, r, seg[1])
+		}
+	}
+
+	if len(seg) == 0 || seg[0] != "Services" {
+		return notFound(r.URL.Path)
+	}
+
+	if len(seg) == 1 {
+		return s.handleServices(w, r)
+	}
+
+	svc := s.findService(seg[1])
+	if svc == nil {
+		return notFound(r.URL.Path)
+	}
+
+	if len(seg) == 2 {
+		return s.handleService(w, r, svc)
+	}
+
+	switch seg[2] {
+	case "Conversations":
+		return s.dispatchConversation(w, r, svc, seg[3:])
+	case "Channels":
+		if len(seg) == 3 {
+			return s.handleChannels(w, r, svc)
+		}
+
+		ch := svc.findChannel(seg[3])
+		if ch == nil {
+			return notFound(r.URL.Path)
+		}
+
+		switch {
+		case len(seg) == 4:
+			return s.handleChannel(w, r, svc, ch)
+		case seg[4] == "Messages" && len(seg) == 5:
+			return s.handleMessages(w, r, ch)
+		case seg[4] == "Messages" && len(seg) == 6:
+			return s.handleMessage(w, r, ch, seg[5])
+		case seg[4] == "Members" && len(seg) == 5:
+			return s.handleMembers(w, r, svc, ch)
+		case seg[4] == "Members" && len(seg) == 6:
+			return s.handleMember(w, r, ch, seg[5])
+		case seg[4] == "Webhooks" && len(seg) == 5:
+			return s.handleWebhooks(w, r, ch)
+		case seg[4] == "Webhooks" && len(seg) == 6:
+			return s.handleWebhook(w, r, ch, seg[5])
+		}
+	case "Users":
+		if len(seg) == 3 {
+			return s.handleUsers(w, r, svc)
+		}
+
+		if len(seg) == 4 {
+			return s.handleUser(w, r, svc, seg[3])
+		}
+
+		user := svc.findUser(seg[3])
+		if user == nil {
+			return notFound(r.URL.Path)
+		}
+
+		switch {
+		case seg[4] == "Bindings" && len(seg) == 5:
+			return s.handleBindings(w, r, svc, user)
+		case seg[4] == "Bindings" && len(seg) == 6:
+			return s.handleBinding(w, r, svc, user, seg[5])
+		}
+	case "Roles":
+		if len(seg) == 3 {
+			return s.handleRoles(w, r, svc)
+		}
+
+		if len(seg) == 4 {
+			return s.handleRole(w, r, svc, seg[3])
+		}
+	}
+
+	return notFound(r.URL.Path)
+}
+
+// Services
+
+func (s *Server) findService(sid string) *service {
+	for _, svc := range s.services {
+		if svc.SID == sid {
+			return svc
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) createService(friendlyName string) *service {
+	sid := NewSID("IS")
+	svc := &service{
+		Service: twiligo.Service{
+			SID:                       sid,
+			AccountSID:                s.AccountSID,
+			FriendlyName:              friendlyName,
+			DateCreated:               now(),
+			DateUpdated:               now(),
+			TypingIndicatorTimeout:    5,
+			ConsumptionReportInterval: 10,
+			ReadStatusEnabled:         true,
+			WebhookMethod:             "POST",
+			Limits:                    &twiligo.ServiceLimits{ChannelMembers: 100, UserChannels: 250},
+			Media:                     &twiligo.ServiceMedia{SizeLimitMB: 150, CompatibilityMessage: "Media messages are not supported by your client"},
+			Notifications: &twiligo.ServiceNotifications{
+				NewMessage:         &twiligo.NotificationConfig{},
+				AddedToChannel:     &twiligo.NotificationConfig{},
+				RemovedFromChannel: &twiligo.NotificationConfig{},
+				InvitedToChannel:   &twiligo.NotificationConfig{},
+			},
+			URL: s.resourceURL("Services", sid),
+			Links: twiligo.ServiceLink{
+				Channels: s.resourceURL("Services", sid, "Channels"),
+				Roles:    s.resourceURL("Services", sid, "Roles"),
+				Users:    s.resourceURL("Services", sid, "Users"),
+				Bindings: s.resourceURL("Services", sid, "Bindings"),
+			},
+		},
+	}
+
+	// Twilio provisions a default role of each kind with every new Service.
+	svc.DefaultServiceRoleSID = s.addRole(svc, "service user", twiligo.RoleTypeDeployment,
+		twiligo.PermissionCreateChannel, twiligo.PermissionJoinChannel, twiligo.PermissionEditOwnUserInfo).SID
+	s.addRole(svc, "service admin", twiligo.RoleTypeDeployment,
+		twiligo.PermissionCreateChannel, twiligo.PermissionJoinChannel, twiligo.PermissionDestroyChannel,
+		twiligo.PermissionEditAnyUserInfo, twiligo.PermissionEditOwnUserInfo)
+	svc.DefaultChannelRoleSID = s.addRole(svc, "channel user", twiligo.RoleTypeChannel,
+		twiligo.PermissionSendMessage, twiligo.PermissionLeaveChannel, twiligo.PermissionEditOwnMessage,
+		twiligo.PermissionDeleteOwnMessage).SID
+	svc.DefaultChannelCreatorRoleSID = s.addRole(svc, "channel admin", twiligo.RoleTypeChannel,
+		twiligo.PermissionSendMessage, twiligo.PermissionLeaveChannel, twiligo.PermissionAddMember,
+		twiligo.PermissionRemoveMember, twiligo.PermissionEditChannelName, twiligo.PermissionEditChannelAttributes,
+		twiligo.PermissionEditAnyMessage, twiligo.PermissionDeleteAnyMessage).SID
+
+	s.services = append(s.services, svc)
+	return svc
+}
+
+func (s *Server) handleServices(w http.ResponseWriter, r *request) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]twiligo.Service, len(s.services))
+		for i, svc := range s.services {
+			items[i] = svc.Service
+		}
+
+		page(s, w, r, "services", items)
+	case http.MethodPost:
+		if r.form.Get("FriendlyName") == "" {
+			return missingParam("FriendlyName")
+		}
+
+		writeJSON(w, http.StatusCreated, s.createService(r.form.Get("FriendlyName")).Service)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleService(w http.ResponseWriter, r *request, svc *service) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, svc.Service)
+	case http.MethodPost:
+		updateString(r, "FriendlyName", &svc.FriendlyName)
+		updateString(r, "DefaultServiceRoleSid", &svc.DefaultServiceRoleSID)
+		updateString(r, "DefaultChannelRoleSid", &svc.DefaultChannelRoleSID)
+		updateString(r, "DefaultChannelCreatorRoleSid", &svc.DefaultChannelCreatorRoleSID)
+		updateString(r, "PreWebhookUrl", &svc.PreWebhookURL)
+		updateString(r, "PostWebhookUrl", &svc.PostWebhookURL)
+		updateString(r, "WebhookMethod", &svc.WebhookMethod)
+
+		if err := updateBool(r, "ReadStatusEnabled", &svc.ReadStatusEnabled); err != nil {
+			return err
+		}
+		if err := updateBool(r, "ReachabilityEnabled", &svc.ReachabilityEnabled); err != nil {
+			return err
+		}
+		if err := updateInt(r, "ConsumptionReportInterval", &svc.ConsumptionReportInterval); err != nil {
+			return err
+		}
+		if err := updateInt(r, "TypingIndicatorTimeout", &svc.TypingIndicatorTimeout); err != nil {
+			return err
+		}
+		if err := updateInt(r, "PreWebhookRetryCount", &svc.PreWebhookRetryCount); err != nil {
+			return err
+		}
+		if err := updateInt(r, "PostWebhookRetryCount", &svc.PostWebhookRetryCount); err != nil {
+			return err
+		}
+		if err := updateInt(r, "Limits.ChannelMembers", &svc.Limits.ChannelMembers); err != nil {
+			return err
+		}
+		if err := updateInt(r, "Limits.UserChannels", &svc.Limits.UserChannels); err != nil {
+			return err
+		}
+		updateString(r, "Media.CompatibilityMessage", &svc.Media.CompatibilityMessage)
+		if err := updateBool(r, "Notifications.LogEnabled", &svc.Notifications.LogEnabled); err != nil {
+			return err
+		}
+		if err := updateBool(r, "Notifications.NewMessage.Enabled", &svc.Notifications.NewMessage.Enabled); err != nil {
+			return err
+		}
+		updateString(r, "Notifications.NewMessage.Template", &svc.Notifications.NewMessage.Template)
+		updateString(r, "Notifications.NewMessage.Sound", &svc.Notifications.NewMessage.Sound)
+		if err := updateBool(r, "Notifications.NewMessage.BadgeCountEnabled", &svc.Notifications.NewMessage.BadgeCountEnabled); err != nil {
+			return err
+		}
+		if err := updateBool(r, "Notifications.AddedToChannel.Enabled", &svc.Notifications.AddedToChannel.Enabled); err != nil {
+			return err
+		}
+		if err := updateBool(r, "Notifications.RemovedFromChannel.Enabled", &svc.Notifications.RemovedFromChannel.Enabled); err != nil {
+			return err
+		}
+		if err := updateBool(r, "Notifications.InvitedToChannel.Enabled", &svc.Notifications.InvitedToChannel.Enabled); err != nil {
+			return err
+		}
+		if r.has("WebhookFilters") {
+			svc.WebhookFilters = r.list("WebhookFilters")
+		}
+
+		svc.DateUpdated = now()
+		writeJSON(w, http.StatusOK, svc.Service)
+	case http.MethodDelete:
+		s.services = slices.DeleteFunc(s.services, func(other *service) bool { return other == svc })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Channels
+
+func (svc *service) findChannel(id string) *channel {
+	for _, ch := range svc.channels {
+		if ch.SID == id || (ch.UniqueName != "" && ch.UniqueName == id) {
+			return ch
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleChannels(w http.ResponseWriter, r *request, svc *service) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]twiligo.Channel, len(svc.channels))
+		for i, ch := range svc.channels {
+			items[i] = ch.view()
+		}
+
+		page(s, w, r, "channels", items)
+	case http.MethodPost:
+		uniqueName := r.form.Get("UniqueName")
+		if uniqueName != "" && svc.findChannel(uniqueName) != nil {
+			return conflict(50307, "Channel with provided unique name already exists")
+		}
+
+		chanType := r.form.Get("Type")
+		switch chanType {
+		case "":
+			chanType = "public"
+		case "public", "private":
+		default:
+			return invalidParam("Type", chanType)
+		}
+
+		attrs, err := attributesParam(r, "")
+		if err != nil {
+			return err
+		}
+
+		createdBy := r.form.Get("CreatedBy")
+		if createdBy == "" {
+			createdBy = "system"
+		}
+
+		sid := NewSID("CH")
+		ch := &channel{
+			Channel: twiligo.Channel{
+				SID:          sid,
+				AccountSID:   s.AccountSID,
+				ServiceSID:   svc.SID,
+				UniqueName:   uniqueName,
+				FriendlyName: r.form.Get("FriendlyName"),
+				Attributes:   attrs,
+				Type:         chanType,
+				DateCreated:  now(),
+				DateUpdated:  now(),
+				CreatedBy:    createdBy,
+				URL:          s.resourceURL("Services", svc.SID, "Channels", sid),
+				Links: twiligo.Link{
+					Members:  s.resourceURL("Services", svc.SID, "Channels", sid, "Members"),
+					Messages: s.resourceURL("Services", svc.SID, "Channels", sid, "Messages"),
+					Webhooks: s.resourceURL("Services", svc.SID, "Channels", sid, "Webhooks"),
+				},
+			},
+		}
+
+		svc.channels = append(svc.channels, ch)
+		writeJSON(w, http.StatusCreated, ch.view())
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleChannel(w http.ResponseWriter, r *request, svc *service, ch *channel) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, ch.view())
+	case http.MethodPost:
+		if r.has("UniqueName") {
+			uniqueName := r.form.Get("UniqueName")
+			if other := svc.findChannel(uniqueName); uniqueName != "" && other != nil && other != ch {
+				return conflict(50307, "Channel with provided unique name already exists")
+			}
+			ch.UniqueName = uniqueName
+		}
+
+		attrs, err := attributesParam(r, ch.Attributes)
+		if err != nil {
+			return err
+		}
+
+		ch.Attributes = attrs
+		updateString(r, "FriendlyName", &ch.FriendlyName)
+		ch.DateUpdated = now()
+		writeJSON(w, http.StatusOK, ch.view())
+	case http.MethodDelete:
+		svc.channels = slices.DeleteFunc(svc.channels, func(other *channel) bool { return other == ch })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// view returns the Channel as Twilio reports it, with its current counts.
+func (ch *channel) view() twiligo.Channel {
+	view := ch.Channel
+	view.MembersCount = len(ch.members)
+	view.MessagesCount = len(ch.messages)
+
+	return view
+}
+
+// Messages
+
+func (ch *channel) findMessage(sid string) *twiligo.Message {
+	for _, msg := range ch.messages {
+		if msg.SID == sid {
+			return msg
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleMessages(w http.ResponseWriter, r *request, ch *channel) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]twiligo.Message, len(ch.messages))
+		for i, msg := range ch.messages {
+			items[i] = *msg
+		}
+
+		if r.URL.Query().Get("Order") == "desc" {
+			slices.Reverse(items)
+		}
+
+		page(s, w, r, "messages", items)
+	case http.MethodPost:
+		if !r.has("Body") && !r.has("MediaSid") {
+			return missingParam("Body")
+		}
+
+		attrs, err := attributesParam(r, "")
+		if err != nil {
+			return err
+		}
+
+		from := r.form.Get("From")
+		if from == "" {
+			from = "system"
+		}
+
+		sid := NewSID("IM")
+		msg := &twiligo.Message{
+			SID:         sid,
+			AccountSID:  s.AccountSID,
+			ServiceSID:  ch.ServiceSID,
+			ChannelSID:  ch.SID,
+			To:          ch.SID,
+			Type:        "text",
+			DateCreated: now(),
+			DateUpdated: now(),
+			From:        from,
+			Body:        r.form.Get("Body"),
+			Attributes:  attrs,
+			Index:       ch.nextIndex,
+			URL:         s.resourceURL("Services", ch.ServiceSID, "Channels", ch.SID, "Messages", sid),
+		}
+
+		if r.has("MediaSid") {
+			msg.Type = "media"
+			msg.Media = &twiligo.Media{SID: r.form.Get("MediaSid")}
+		}
+
+		ch.nextIndex++
+		ch.messages = append(ch.messages, msg)
+		writeJSON(w, http.StatusCreated, msg)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleMessage(w http.ResponseWriter, r *request, ch *channel, sid string) *errorResponse {
+	msg := ch.findMessage(sid)
+	if msg == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, msg)
+	case http.MethodPost:
+		attrs, err := attributesParam(r, msg.Attributes)
+		if err != nil {
+			return err
+		}
+
+		if r.has("Body") && r.form.Get("Body") != msg.Body {
+			msg.Body = r.form.Get("Body")
+			msg.WasEdited = true
+		}
+
+		msg.Attributes = attrs
+		msg.DateUpdated = now()
+		writeJSON(w, http.StatusOK, msg)
+	case http.MethodDelete:
+		ch.messages = slices.DeleteFunc(ch.messages, func(other *twiligo.Message) bool { return other == msg })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Members
+
+func (ch *channel) findMember(id string) *twiligo.Member {
+	for _, member := range ch.members {
+		if member.SID == id || member.Identity == id {
+			return member
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleMembers(w http.ResponseWriter, r *request, svc *service, ch *channel) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		var items []twiligo.Member
+		identities := r.URL.Query()["Identity"]
+		for _, member := range ch.members {
+			if len(identities) == 0 || slices.Contains(identities, member.Identity) {
+				items = append(items, *member)
+			}
+		}
+
+		page(s, w, r, "members", items)
+	case http.MethodPost:
+		identity := r.form.Get("Identity")
+		if identity == "" {
+			return missingParam("Identity")
+		}
+
+		if ch.findMember(identity) != nil {
+			return conflict(50404, "Member already exists")
+		}
+
+		if len(ch.members) >= svc.Limits.ChannelMembers {
+			return conflict(50403, "Channel member limit exceeded")
+		}
+
+		attrs, err := attributesParam(r, "")
+		if err != nil {
+			return err
+		}
+
+		roleSID := r.form.Get("RoleSid")
+		if roleSID == "" {
+			roleSID = svc.DefaultChannelRoleSID
+		} else if svc.findRole(roleSID) == nil {
+			return invalidParam("RoleSid", roleSID)
+		}
+
+		// Twilio creates Users on the fly when they're added to a Channel.
+		if svc.findUser(identity) == nil {
+			s.addUser(svc, identity, "", "{}", "")
+		}
+
+		sid := NewSID("MB")
+		member := &twiligo.Member{
+			SID:         sid,
+			AccountSID:  s.AccountSID,
+			ChannelSID:  ch.SID,
+			ServiceSID:  svc.SID,
+			Identity:    identity,
+			RoleSID:     roleSID,
+			Attributes:  attrs,
+			DateCreated: now(),
+			DateUpdated: now(),
+			URL:         s.resourceURL("Services", svc.SID, "Channels", ch.SID, "Members", sid),
+		}
+
+		ch.members = append(ch.members, member)
+		writeJSON(w, http.StatusCreated, member)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleMember(w http.ResponseWriter, r *request, ch *channel, id string) *errorResponse {
+	member := ch.findMember(id)
+	if member == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, member)
+	case http.MethodPost:
+		attrs, err := attributesParam(r, member.Attributes)
+		if err != nil {
+			return err
+		}
+
+		member.Attributes = attrs
+		updateString(r, "RoleSid", &member.RoleSID)
+
+		if r.has("LastConsumedMessageIndex") {
+			index, err := strconv.Atoi(r.form.Get("LastConsumedMessageIndex"))
+			if err != nil {
+				return invalidParam("LastConsumedMessageIndex", r.form.Get("LastConsumedMessageIndex"))
+			}
+
+			member.LastConsumedMessageIndex = &index
+			member.LastConsumptionTimestamp = now()
+		}
+
+		member.DateUpdated = now()
+		writeJSON(w, http.StatusOK, member)
+	case http.MethodDelete:
+		ch.members = slices.DeleteFunc(ch.members, func(other *twiligo.Member) bool { return other == member })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Users
+
+func (svc *service) findUser(id string) *twiligo.User {
+	for _, user := range svc.users {
+		if user.SID == id || user.Identity == id {
+			return user
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) addUser(svc *service, identity, friendlyName, attributes, roleSID string) *twiligo.User {
+	if roleSID == "" {
+		roleSID = svc.DefaultServiceRoleSID
+	}
+
+	sid := NewSID("US")
+	user := &twiligo.User{
+		SID:          sid,
+		AccountSID:   s.AccountSID,
+		ServiceSID:   svc.SID,
+		RoleSID:      roleSID,
+		Identity:     identity,
+		FriendlyName: friendlyName,
+		Attributes:   attributes,
+		DateCreated:  now(),
+		DateUpdated:  now(),
+		URL:          s.resourceURL("Services", svc.SID, "Users", sid),
+		Links: twiligo.UserLink{
+			UserChannels: s.resourceURL("Services", svc.SID, "Users", sid, "Channels"),
+			UserBindings: s.resourceURL("Services", svc.SID, "Users", sid, "Bindings"),
+		},
+	}
+
+	svc.users = append(svc.users, user)
+	return user
+}
+
+func (s *Server) handleUsers(w http.ResponseWriter, r *request, svc *service) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]twiligo.User, len(svc.users))
+		for i, user := range svc.users {
+			items[i] = svc.userView(user)
+		}
+
+		page(s, w, r, "users", items)
+	case http.MethodPost:
+		identity := r.form.Get("Identity")
+		if identity == "" {
+			return missingParam("Identity")
+		}
+
+		if svc.findUser(identity) != nil {
+			return conflict(50201, "User with this identity already exists")
+		}
+
+		roleSID := r.form.Get("RoleSid")
+		if roleSID != "" && svc.findRole(roleSID) == nil {
+			return invalidParam("RoleSid", roleSID)
+		}
+
+		attrs, err := attributesParam(r, "")
+		if err != nil {
+			return err
+		}
+
+		user := s.addUser(svc, identity, r.form.Get("FriendlyName"), attrs, roleSID)
+		writeJSON(w, http.StatusCreated, svc.userView(user))
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *request, svc *service, id string) *errorResponse {
+	user := svc.findUser(id)
+	if user == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, svc.userView(user))
+	case http.MethodPost:
+		if r.has("RoleSid") && svc.findRole(r.form.Get("RoleSid")) == nil {
+			return invalidParam("RoleSid", r.form.Get("RoleSid"))
+		}
+
+		attrs, err := attributesParam(r, user.Attributes)
+		if err != nil {
+			return err
+		}
+
+		user.Attributes = attrs
+		updateString(r, "FriendlyName", &user.FriendlyName)
+		updateString(r, "RoleSid", &user.RoleSID)
+		user.DateUpdated = now()
+		writeJSON(w, http.StatusOK, svc.userView(user))
+	case http.MethodDelete:
+		svc.users = slices.DeleteFunc(svc.users, func(other *twiligo.User) bool { return other == user })
+		svc.bindings = slices.DeleteFunc(svc.bindings, func(binding *twiligo.Binding) bool { return binding.UserSID == user.SID })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// userView returns the User as Twilio reports it, with its current count of joined Channels.
+func (svc *service) userView(user *twiligo.User) twiligo.User {
+	view := *user
+	for _, ch := range svc.channels {
+		if ch.findMember(user.Identity) != nil {
+			view.JoinedChannelsCount++
+		}
+	}
+
+	return view
+}
+
+// Bindings
+
+func (svc *service) findBinding(user *twiligo.User, sid string) *twiligo.Binding {
+	for _, binding := range svc.bindings {
+		if binding.SID == sid && binding.UserSID == user.SID {
+			return binding
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleBindings(w http.ResponseWriter, r *request, svc *service, user *twiligo.User) *errorResponse {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(r.Method)
+	}
+
+	var items []twiligo.Binding
+	bindingTypes := r.URL.Query()["BindingType"]
+	for _, binding := range svc.bindings {
+		if binding.UserSID != user.SID {
+			continue
+		}
+
+		if len(bindingTypes) == 0 || slices.Contains(bindingTypes, string(binding.BindingType)) {
+			items = append(items, *binding)
+		}
+	}
+
+	page(s, w, r, "bindings", items)
+	return nil
+}
+
+func (s *Server) handleBinding(w http.ResponseWriter, r *request, svc *service, user *twiligo.User, sid string) *errorResponse {
+	binding := svc.findBinding(user, sid)
+	if binding == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, binding)
+	case http.MethodDelete:
+		svc.bindings = slices.DeleteFunc(svc.bindings, func(other *twiligo.Binding) bool { return other == binding })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Roles
+
+func (svc *service) findRole(sid string) *twiligo.Role {
+	for _, role := range svc.roles {
+		if role.SID == sid {
+			return role
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) addRole(svc *service, friendlyName string, roleType twiligo.RoleType, permissions ...twiligo.Permission) *twiligo.Role {
+	sid := NewSID("RL")
+	role := &twiligo.Role{
+		SID:          sid,
+		AccountSID:   s.AccountSID,
+		ServiceSID:   svc.SID,
+		FriendlyName: friendlyName,
+		Type:         roleType,
+		Permissions:  permissions,
+		DateCreated:  now(),
+		DateUpdated:  now(),
+		URL:          s.resourceURL("Services", svc.SID, "Roles", sid),
+	}
+
+	svc.roles = append(svc.roles, role)
+	return role
+}
+
+func permissionsParam(r *request) ([]twiligo.Permission, *errorResponse) {
+	values := r.form["Permission"]
+	if len(values) == 0 {
+		return nil, missingParam("Permission")
+	}
+
+	permissions := make([]twiligo.Permission, len(values))
+	for i, value := range values {
+		permissions[i] = twiligo.Permission(value)
+	}
+
+	return permissions, nil
+}
+
+func (s *Server) handleRoles(w http.ResponseWriter, r *request, svc *service) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]twiligo.Role, len(svc.roles))
+		for i, role := range svc.roles {
+			items[i] = *role
+		}
+
+		page(s, w, r, "roles", items)
+	case http.MethodPost:
+		friendlyName := r.form.Get("FriendlyName")
+		if friendlyName == "" {
+			return missingParam("FriendlyName")
+		}
+
+		for _, role := range svc.roles {
+			if role.FriendlyName == friendlyName {
+				return conflict(50353, fmt.Sprintf("Role with friendly name %s already exists", friendlyName))
+			}
+		}
+
+		roleType := twiligo.RoleType(r.form.Get("Type"))
+		if roleType != twiligo.RoleTypeChannel && roleType != twiligo.RoleTypeDeployment {
+			return invalidParam("Type", string(roleType))
+		}
+
+		permissions, err := permissionsParam(r)
+		if err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusCreated, s.addRole(svc, friendlyName, roleType, permissions...))
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleRole(w http.ResponseWriter, r *request, svc *service, sid string) *errorResponse {
+	role := svc.findRole(sid)
+	if role == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, role)
+	case http.MethodPost:
+		permissions, err := permissionsParam(r)
+		if err != nil {
+			return err
+		}
+
+		role.Permissions = permissions
+		role.DateUpdated = now()
+		writeJSON(w, http.StatusOK, role)
+	case http.MethodDelete:
+		svc.roles = slices.DeleteFunc(svc.roles, func(other *twiligo.Role) bool { return other == role })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Channel webhooks
+
+func (ch *channel) findWebhook(sid string) *twiligo.ChannelWebhook {
+	for _, webhook := range ch.webhooks {
+		if webhook.SID == sid {
+			return webhook
+		}
+	}
+
+	return nil
+}
+
+// updateWebhookConfiguration applies the Configuration.* parameters of a request to config.
+func updateWebhookConfiguration(r *request, config *twiligo.WebhookConfiguration) *errorResponse {
+	updateString(r, "Configuration.Url", &config.URL)
+	updateString(r, "Configuration.Method", &config.Method)
+	updateString(r, "Configuration.FlowSid", &config.FlowSID)
+
+	if r.has("Configuration.Filters") {
+		config.Filters = r.list("Configuration.Filters")
+	}
+	if r.has("Configuration.Triggers") {
+		config.Triggers = r.list("Configuration.Triggers")
+	}
+
+	return updateInt(r, "Configuration.RetryCount", &config.RetryCount)
+}
+
+func (s *Server) handleWebhooks(w http.ResponseWriter, r *request, ch *channel) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]twiligo.ChannelWebhook, len(ch.webhooks))
+		for i, webhook := range ch.webhooks {
+			items[i] = *webhook
+		}
+
+		page(s, w, r, "webhooks", items)
+	case http.MethodPost:
+		webhookType := twiligo.ChannelWebhookType(r.form.Get("Type"))
+		switch webhookType {
+		case "":
+			return missingParam("Type")
+		case twiligo.ChannelWebhookTypeWebhook, twiligo.ChannelWebhookTypeTrigger, twiligo.ChannelWebhookTypeStudio:
+		default:
+			return invalidParam("Type", string(webhookType))
+		}
+
+		sid := NewSID("WH")
+		webhook := &twiligo.ChannelWebhook{
+			SID:         sid,
+			AccountSID:  s.AccountSID,
+			ServiceSID:  ch.ServiceSID,
+			ChannelSID:  ch.SID,
+			Type:        webhookType,
+			DateCreated: now(),
+			DateUpdated: now(),
+			URL:         s.resourceURL("Services", ch.ServiceSID, "Channels", ch.SID, "Webhooks", sid),
+		}
+
+		if err := updateWebhookConfiguration(r, &webhook.Configuration); err != nil {
+			return err
+		}
+
+		if webhookType == twiligo.ChannelWebhookTypeStudio && webhook.Configuration.FlowSID == "" {
+			return missingParam("Configuration.FlowSid")
+		}
+
+		if webhookType != twiligo.ChannelWebhookTypeStudio && webhook.Configuration.URL == "" {
+			return missingParam("Configuration.Url")
+		}
+
+		ch.webhooks = append(ch.webhooks, webhook)
+		writeJSON(w, http.StatusCreated, webhook)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleWebhook(w http.ResponseWriter, r *request, ch *channel, sid string) *errorResponse {
+	webhook := ch.findWebhook(sid)
+	if webhook == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, webhook)
+	case http.MethodPost:
+		if err := updateWebhookConfiguration(r, &webhook.Configuration); err != nil {
+			return err
+		}
+
+		webhook.DateUpdated = now()
+		writeJSON(w, http.StatusOK, webhook)
+	case http.MethodDelete:
+		ch.webhooks = slices.DeleteFunc(ch.webhooks, func(other *twiligo.ChannelWebhook) bool { return other == webhook })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Credentials
+
+func (s *Server) findCredential(sid string) *twiligo.Credential {
+	for _, credential := range s.credentials {
+		if credential.SID == sid {
+			return credential
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) handleCredentials(w http.ResponseWriter, r *request) *errorResponse {
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]twiligo.Credential, len(s.credentials))
+		for i, credential := range s.credentials {
+			items[i] = *credential
+		}
+
+		page(s, w, r, "credentials", items)
+	case http.MethodPost:
+		credType := twiligo.BindingType(r.form.Get("Type"))
+		switch credType {
+		case "":
+			return missingParam("Type")
+		case twiligo.BindingTypeAPN, twiligo.BindingTypeGCM, twiligo.BindingTypeFCM:
+		default:
+			return invalidParam("Type", string(credType))
+		}
+
+		sandbox := false
+		if err := updateBool(r, "Sandbox", &sandbox); err != nil {
+			return err
+		}
+
+		sid := NewSID("CR")
+		credential := &twiligo.Credential{
+			SID:          sid,
+			AccountSID:   s.AccountSID,
+			FriendlyName: r.form.Get("FriendlyName"),
+			Type:         credType,
+			Sandbox:      strconv.FormatBool(sandbox),
+			DateCreated:  now(),
+			DateUpdated:  now(),
+			URL:          s.resourceURL("Credentials", sid),
+		}
+
+		s.credentials = append(s.credentials, credential)
+		writeJSON(w, http.StatusCreated, credential)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+func (s *Server) handleCredential(w http.ResponseWriter, r *request, sid string) *errorResponse {
+	credential := s.findCredential(sid)
+	if credential == nil {
+		return notFound(r.URL.Path)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, credential)
+	case http.MethodPost:
+		if r.has("Sandbox") {
+			sandbox, err := strconv.ParseBool(r.form.Get("Sandbox"))
+			if err != nil {
+				return invalidParam("Sandbox", r.form.Get("Sandbox"))
+			}
+			credential.Sandbox = strconv.FormatBool(sandbox)
+		}
+
+		updateString(r, "FriendlyName", &credential.FriendlyName)
+		credential.DateUpdated = now()
+		writeJSON(w, http.StatusOK, credential)
+	case http.MethodDelete:
+		s.credentials = slices.DeleteFunc(s.credentials, func(other *twiligo.Credential) bool { return other == credential })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return methodNotAllowed(r.Method)
+	}
+
+	return nil
+}
+
+// Form helpers
+
+func updateString(r *request, name string, field *string) {
+	if r.has(name) {
+		*field = r.form.Get(name)
+	}
+}
+
+func updateBool(r *request, name string, field *bool) *errorResponse {
+	if !r.has(name) {
+		return nil
+	}
+
+	value, err := strconv.ParseBool(r.form.Get(name))
+	if err != nil {
+		return invalidParam(name, r.form.Get(name))
+	}
+
+	*field = value
+	return nil
+}
+
+func updateInt(r *request, name string, field *int) *errorResponse {
+	if !r.has(name) {
+		return nil
+	}
+
+	value, err := strconv.Atoi(r.form.Get(name))
+	if err != nil {
+		return invalidParam(name, r.form.Get(name))
+	}
+
+	*field = value
+	return nil
+}
@@ -0,0 +1,373 @@
+// Package twiligotest provides an in-memory fake of the Twilio Chat API for testing code that uses
+// a twiligo.Client without talking to Twilio. The fake keeps its state in memory, hands out
+// realistic SIDs, enforces unique names and identities, pages list responses and reports failures
+// with Twilio shaped errors. It also serves the Service scoped part of the Conversations API for
+// code using a conversations.Client.
+package twiligotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eriktate/twiligo"
+)
+
+// DefaultAuthToken is the auth token a Server accepts unless its AuthToken is changed.
+const DefaultAuthToken = "twiligotest-auth-token"
+
+// defaultPageSize matches the page size Twilio uses when none is requested.
+const defaultPageSize = 50
+
+// maxPageSize matches the largest page size Twilio allows.
+const maxPageSize = 100
+
+// Server is a fake Twilio Chat API backed by an httptest.Server. Point a Client at it with
+// twiligo.NewClient(server.URL, ...) or use Server.Client.
+type Server struct {
+	*httptest.Server
+
+	// AccountSID is the account every resource belongs to.
+	AccountSID string
+	// AuthToken is the password accepted for AccountSID. Requests authenticated with an API Key
+	// (a username starting with SK) are accepted with any secret.
+	AuthToken string
+
+	mu          sync.Mutex
+	services    []*service
+	credentials []*twiligo.Credential
+}
+
+// service holds the state of a single Chat Service and everything within it.
+type service struct {
+	twiligo.Service
+
+	channels []*channel
+	users    []*twiligo.User
+	roles    []*twiligo.Role
+	bindings []*twiligo.Binding
+
+	conversations []*conversation
+}
+
+type channel struct {
+	twiligo.Channel
+
+	messages  []*twiligo.Message
+	members   []*twiligo.Member
+	webhooks  []*twiligo.ChannelWebhook
+	nextIndex int
+}
+
+// NewServer starts a new fake Twilio Chat API. Callers should Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		AccountSID: NewSID("AC"),
+		AuthToken:  DefaultAuthToken,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns a twiligo.Client authenticated against the Server and scoped to the given Service.
+func (s *Server) Client(serviceSID string, opts ...twiligo.Option) *twiligo.Client {
+	return twiligo.NewClient(s.URL, s.AccountSID, serviceSID, s.AuthToken, opts...)
+}
+
+// AddService creates a Service directly in the fake's state, for seeding tests.
+func (s *Server) AddService(friendlyName string) twiligo.Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createService(friendlyName).Service
+}
+
+// AddBinding registers a push notification Binding for a User directly in the fake's state, creating
+// the User when needed. Twilio only creates Bindings through its client SDKs, so this is the only way
+// to seed them. It panics if the Service doesn't exist.
+func (s *Server) AddBinding(serviceSID, identity string, bindingType twiligo.BindingType, endpoint string) twiligo.Binding {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc := s.findService(serviceSID)
+	if svc == nil {
+		panic(fmt.Sprintf("twiligotest: no Service with SID %s", serviceSID))
+	}
+
+	user := svc.findUser(identity)
+	if user == nil {
+		user = s.addUser(svc, identity, "", "{}", "")
+	}
+
+	sid := NewSID("BS")
+	binding := &twiligo.Binding{
+		SID:          sid,
+		AccountSID:   s.AccountSID,
+		ServiceSID:   svc.SID,
+		UserSID:      user.SID,
+		Identity:     identity,
+		Endpoint:     endpoint,
+		BindingType:  bindingType,
+		MessageTypes: []string{"new_message"},
+		DateCreated:  now(),
+		DateUpdated:  now(),
+		URL:          s.resourceURL("Services", svc.SID, "Bindings", sid),
+	}
+
+	svc.bindings = append(svc.bindings, binding)
+	return *binding
+}
+
+// NewSID returns a random SID with the given two letter prefix, e.g. NewSID("CH").
+func NewSID(prefix string) string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return prefix + hex.EncodeToString(b)
+}
+
+// apiError is the body of a Twilio error response.
+type apiError struct {
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	MoreInfo string `json:"more_info"`
+	Status   int    `json:"status"`
+}
+
+// errorResponse is a Twilio shaped error to be written back to the client.
+type errorResponse struct {
+	status int
+	code   int
+	msg    string
+}
+
+func (e *errorResponse) Error() string {
+	return e.msg
+}
+
+func notFound(path string) *errorResponse {
+	return &errorResponse{http.StatusNotFound, 20404, fmt.Sprintf("The requested resource %s was not found", path)}
+}
+
+func missingParam(name string) *errorResponse {
+	return &errorResponse{http.StatusBadRequest, 20001, fmt.Sprintf("Missing required parameter %s in the post body", name)}
+}
+
+func invalidParam(name, value string) *errorResponse {
+	return &errorResponse{http.StatusBadRequest, 20001, fmt.Sprintf("Invalid %s: %s", name, value)}
+}
+
+func conflict(code int, msg string) *errorResponse {
+	return &errorResponse{http.StatusConflict, code, msg}
+}
+
+func methodNotAllowed(method string) *errorResponse {
+	return &errorResponse{http.StatusMethodNotAllowed, 20004, fmt.Sprintf("Method %s not allowed", method)}
+}
+
+func writeError(w http.ResponseWriter, err *errorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.status)
+	json.NewEncoder(w).Encode(apiError{
+		Code:     err.code,
+		Message:  err.msg,
+		MoreInfo: fmt.Sprintf("https://www.twilio.com/docs/errors/%d", err.code),
+		Status:   err.status,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// request is an incoming API request with its path split into unescaped segments.
+type request struct {
+	*http.Request
+
+	segments []string
+	form     url.Values
+}
+
+// has reports whether a parameter was sent, which is how partial updates are told apart.
+func (r *request) has(name string) bool {
+	_, ok := r.form[name]
+	return ok
+}
+
+// list returns the values of a repeated parameter, where a single empty value is an empty list.
+func (r *request) list(name string) []string {
+	values := []string{}
+	for _, value := range r.form[name] {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, &errorResponse{http.StatusUnauthorized, 20003, "Authenticate"})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, &errorResponse{http.StatusBadRequest, 20001, err.Error()})
+		return
+	}
+
+	segments, err := splitPath(r.URL.EscapedPath())
+	if err != nil {
+		writeError(w, notFound(r.URL.Path))
+		return
+	}
+
+	// Clients may point at the server with or without an API version.
+	if len(segments) > 0 && (segments[0] == "v1" || segments[0] == "v2") {
+		segments = segments[1:]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.route(w, &request{Request: r, segments: segments, form: r.PostForm})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	if username == s.AccountSID {
+		return password == s.AuthToken
+	}
+
+	return strings.HasPrefix(username, "SK") && password != ""
+}
+
+// splitPath splits an escaped path into its unescaped segments, so identities and unique names
+// containing reserved characters are matched correctly.
+func splitPath(escaped string) ([]string, error) {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(escaped, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+
+		segments = append(segments, unescaped)
+	}
+
+	return segments, nil
+}
+
+// resourceURL builds the absolute URL of a resource from its path segments.
+func (s *Server) resourceURL(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+
+	return s.URL + "/" + strings.Join(escaped, "/")
+}
+
+// page writes one page of a list response, shaped like Twilio's with a meta block keyed by key.
+func page[T any](s *Server, w http.ResponseWriter, r *request, key string, items []T) {
+	query := r.URL.Query()
+
+	pageSize := defaultPageSize
+	if raw := query.Get("PageSize"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size < 1 {
+			writeError(w, invalidParam("PageSize", raw))
+			return
+		}
+		pageSize = min(size, maxPageSize)
+	}
+
+	pageNum, _ := strconv.Atoi(query.Get("Page"))
+	if pageNum < 0 {
+		pageNum = 0
+	}
+
+	start := min(pageNum*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+
+	listURL := s.resourceURL(r.segments...)
+	pageURL := func(n int) string {
+		params := url.Values{"PageSize": {strconv.Itoa(pageSize)}, "Page": {strconv.Itoa(n)}}
+		if order := query.Get("Order"); order != "" {
+			params.Set("Order", order)
+		}
+
+		return listURL + "?" + params.Encode()
+	}
+
+	meta := map[string]any{
+		"page":              pageNum,
+		"page_size":         pageSize,
+		"first_page_url":    pageURL(0),
+		"previous_page_url": nil,
+		"url":               pageURL(pageNum),
+		"next_page_url":     nil,
+		"key":               key,
+	}
+
+	if pageNum > 0 {
+		meta["previous_page_url"] = pageURL(pageNum - 1)
+	}
+
+	if end < len(items) {
+		meta["next_page_url"] = pageURL(pageNum + 1)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		key:    items[start:end],
+		"meta": meta,
+	})
+}
+
+// now returns the current time the way Twilio reports it, in UTC with second precision.
+func now() *time.Time {
+	t := time.Now().UTC().Truncate(time.Second)
+	return &t
+}
+
+// attributesParam validates the Attributes parameter, defaulting to an empty object like Twilio.
+func attributesParam(r *request, current string) (string, *errorResponse) {
+	if !r.has("Attributes") {
+		if current == "" {
+			return "{}", nil
+		}
+		return current, nil
+	}
+
+	attrs := r.form.Get("Attributes")
+	if attrs == "" {
+		return "{}", nil
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(attrs), &obj); err != nil {
+		return "", invalidParam("Attributes", attrs)
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,16 @@
+package twiligo
+
+// String returns a pointer to the given string, for setting fields of update params.
+func String(v string) *string {
+	return &v
+}
+
+// Bool returns a pointer to the given bool, for setting fields of update params.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Int returns a pointer to the given int, for setting fields of update params.
+func Int(v int) *int {
+	return &v
+}
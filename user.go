@@ -1,25 +1,43 @@
 package twiligo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"time"
+
+	"github.com/eriktate/twiligo/internal/rest"
 )
 
 type User struct {
-	SID          string     `json:"sid,omitempty"`
-	AccountSID   string     `json:"account_sid,omitempty"`
-	ServiceSID   string     `json:"service_sid,omitempty"`
-	RoleSID      string     `json:"role_sid,omitempty"`
-	Identity     string     `json:"identity,omitempty"`
-	FriendlyName string     `json:"friendly_name,omitempty"`
-	Attributes   string     `json:"attributes,omitempty"`
-	DateCreated  *time.Time `json:"date_created,omitempty"`
-	DateUpdated  *time.Time `json:"date_updated,omitempty"`
-	Online       bool       `json:"is_online,omitempty"`
-	Notifiable   bool       `json:"is_notifiable,omitempty"`
-	URL          string     `json:"url,omitempty"`
+	SID                 string     `json:"sid,omitempty"`
+	AccountSID          string     `json:"account_sid,omitempty"`
+	ServiceSID          string     `json:"service_sid,omitempty"`
+	RoleSID             string     `json:"role_sid,omitempty"`
+	Identity            string     `json:"identity,omitempty"`
+	FriendlyName        string     `json:"friendly_name,omitempty"`
+	Attributes          string     `json:"attributes,omitempty"`
+	DateCreated         *time.Time `json:"date_created,omitempty"`
+	DateUpdated         *time.Time `json:"date_updated,omitempty"`
+	Online              bool       `json:"is_online,omitempty"`
+	Notifiable          bool       `json:"is_notifiable,omitempty"`
+	JoinedChannelsCount int        `json:"joined_channels_count,omitempty"` // Only returned by v2.
+	URL                 string     `json:"url,omitempty"`
+	Links               UserLink   `json:"links,omitempty"`
+}
+
+// UserLink is the structured representation of a Link within a User.
+type UserLink struct {
+	UserChannels string `json:"user_channels,omitempty"`
+	UserBindings string `json:"user_bindings,omitempty"`
+}
+
+// UsersResponse is the structured representation of a response from Twilio for multiple Users.
+type UsersResponse struct {
+	Users []User `json:"users,omitempty"`
+	Meta  Meta   `json:"meta"`
 }
 
 // NewUser creates a new instances of a User with the required fields.
@@ -33,9 +51,13 @@ func NewUser(identity, friendlyName, attributes, roleSID string) User {
 }
 
 // CreateUser creates a new User in Twilio.
-func (c *Client) CreateUser(user User) (User, error) {
+func (c *Client) CreateUser(ctx context.Context, user User) (User, error) {
 	var createdUser User
 
+	if err := rest.ValidateAttributes(user.Attributes); err != nil {
+		return createdUser, err
+	}
+
 	form := url.Values{}
 	form.Add("Identity", user.Identity)
 	form.Add("FriendlyName", user.FriendlyName)
@@ -43,7 +65,7 @@ func (c *Client) CreateUser(user User) (User, error) {
 	form.Add("RoleSid", user.RoleSID)
 	payload := []byte(form.Encode())
 
-	data, err := c.postResource("Users", payload, getFormHeader())
+	data, err := c.postResource(ctx, "Users", payload, getFormHeader())
 
 	if err != nil {
 		return createdUser, err
@@ -56,11 +78,18 @@ func (c *Client) CreateUser(user User) (User, error) {
 	return createdUser, nil
 }
 
-// User retrieves a specific User from Twilio.
-func (c *Client) User(identity string) (User, error) {
+// User retrieves a specific User from Twilio. The identity can either be the SID for the user or
+// the identity it was created with.
+func (c *Client) User(ctx context.Context, identity string) (User, error) {
 	var user User
 
-	data, err := c.getResource(fmt.Sprintf("Users/%s", identity), nil)
+	path, err := rest.Path("Users", identity)
+
+	if err != nil {
+		return user, err
+	}
+
+	data, err := c.getResource(ctx, path, nil)
 
 	if err != nil {
 		return user, err
@@ -73,32 +102,79 @@ func (c *Client) User(identity string) (User, error) {
 	return user, nil
 }
 
-// UpdateUser updates an existing User in Twilio.
-func (c *Client) UpdateUser(user User) (User, error) {
+// Users retrieves all Users in the Client's Service, following every page of results unless a Limit
+// is given.
+func (c *Client) Users(ctx context.Context, opts ...ListOption) ([]User, error) {
+	listURL, err := c.resourceURL("Users")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listAll[User](ctx, c, listURL, opts)
+}
+
+// IterUsers lazily iterates over the Users in the Client's Service, fetching pages on demand.
+func (c *Client) IterUsers(ctx context.Context, opts ...ListOption) iter.Seq2[User, error] {
+	listURL, err := c.resourceURL("Users")
+
+	if err != nil {
+		return rest.Failed[User](err)
+	}
+
+	return iterate[User](ctx, c, listURL, opts)
+}
+
+// UserUpdate describes changes to a User. Only the fields that are set are sent to Twilio.
+type UserUpdate struct {
+	FriendlyName *string
+	Attributes   *string
+	RoleSID      *string
+}
+
+// UpdateUser updates an existing User in Twilio, leaving any field not set in the update as it is.
+// The sid can either be the SID for the user or its identity.
+func (c *Client) UpdateUser(ctx context.Context, sid string, update UserUpdate) (User, error) {
 	var updatedUser User
 
+	if err := rest.ValidateAttributesUpdate(update.Attributes); err != nil {
+		return updatedUser, err
+	}
+
 	form := url.Values{}
-	form.Add("FriendlyName", user.FriendlyName)
-	form.Add("Attributes", user.Attributes)
-	form.Add("RoleSid", user.RoleSID)
+	rest.SetString(form, "FriendlyName", update.FriendlyName)
+	rest.SetString(form, "Attributes", update.Attributes)
+	rest.SetString(form, "RoleSid", update.RoleSID)
 	payload := []byte(form.Encode())
 
-	data, err := c.postResource(fmt.Sprintf("Users/%s", user.SID), payload, getFormHeader())
+	path, err := rest.Path("Users", sid)
 
 	if err != nil {
-		return user, err
+		return updatedUser, err
+	}
+
+	data, err := c.postResource(ctx, path, payload, getFormHeader())
+
+	if err != nil {
+		return updatedUser, err
 	}
 
 	if err = json.Unmarshal(data, &updatedUser); err != nil {
-		return user, err
+		return updatedUser, err
 	}
 
-	return user, nil
+	return updatedUser, nil
 }
 
 // DeleteUser deletes an existing User from Twilio.
-func (c *Client) DeleteUser(sid string) error {
-	data, err := c.deleteResource(fmt.Sprintf("Users/%s", sid))
+func (c *Client) DeleteUser(ctx context.Context, sid string) error {
+	path, err := rest.Path("Users", sid)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := c.deleteResource(ctx, path)
 
 	if err != nil {
 		return err
@@ -0,0 +1,63 @@
+package twiligo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedVersion is returned when calling an endpoint the Client's API version doesn't have.
+var ErrUnsupportedVersion = errors.New("twiligo: endpoint not supported by API version")
+
+// APIVersion is a version of the Programmable Chat API.
+type APIVersion string
+
+// The versions of the Programmable Chat API a Client can talk to.
+const (
+	V1 APIVersion = "v1"
+	V2 APIVersion = "v2"
+)
+
+// WithAPIVersion selects the version of the Chat API, replacing the version at the end of the base
+// URL or appending it when there is none. It applies to the final base URL, whatever the order of
+// the options.
+func WithAPIVersion(version APIVersion) Option {
+	return func(c *Client) {
+		c.version = version
+	}
+}
+
+// withVersion replaces the version at the end of baseURL, or appends it when there is none.
+func withVersion(baseURL string, version APIVersion) string {
+	if current := versionOf(baseURL); current != "" {
+		baseURL = strings.TrimSuffix(baseURL, "/"+string(current))
+	}
+
+	return baseURL + "/" + string(version)
+}
+
+// APIVersion returns the version of the Chat API the Client talks to, based on its base URL. It's
+// empty when the base URL doesn't end in a version, e.g. for a proxy.
+func (c *Client) APIVersion() APIVersion {
+	return versionOf(c.baseURL)
+}
+
+func versionOf(baseURL string) APIVersion {
+	switch {
+	case strings.HasSuffix(baseURL, "/"+string(V1)):
+		return V1
+	case strings.HasSuffix(baseURL, "/"+string(V2)):
+		return V2
+	}
+
+	return ""
+}
+
+// requireV2 fails for endpoints that only exist in v2 when the Client is known to talk to v1.
+func (c *Client) requireV2(endpoint string) error {
+	if c.APIVersion() == V1 {
+		return fmt.Errorf("%w: %s requires %s", ErrUnsupportedVersion, endpoint, V2)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package twiligo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eriktate/twiligo"
+)
+
+func TestWithAPIVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []twiligo.Option
+		want twiligo.APIVersion
+	}{
+		{"default", nil, twiligo.V2},
+		{"replaces default", []twiligo.Option{twiligo.WithAPIVersion(twiligo.V1)}, twiligo.V1},
+		{"after base URL", []twiligo.Option{twiligo.WithBaseURL("https://proxy.example.com/chat"), twiligo.WithAPIVersion(twiligo.V1)}, twiligo.V1},
+		{"before base URL", []twiligo.Option{twiligo.WithAPIVersion(twiligo.V1), twiligo.WithBaseURL("https://proxy.example.com/chat")}, twiligo.V1},
+		{"replaces base URL version", []twiligo.Option{twiligo.WithAPIVersion(twiligo.V2), twiligo.WithBaseURL("https://proxy.example.com/v1/")}, twiligo.V2},
+		{"proxy without version", []twiligo.Option{twiligo.WithBaseURL("https://proxy.example.com")}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := twiligo.New("AC123", "token", test.opts...)
+			if got := client.APIVersion(); got != test.want {
+				t.Errorf("APIVersion() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestV2EndpointsRejectedByV1(t *testing.T) {
+	client := twiligo.New("AC123", "token", twiligo.WithAPIVersion(twiligo.V1), twiligo.WithBaseURL("https://proxy.example.com"))
+
+	if _, err := client.Credentials(context.Background()); !errors.Is(err, twiligo.ErrUnsupportedVersion) {
+		t.Errorf("Credentials on v1 returned %v, want ErrUnsupportedVersion", err)
+	}
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Decision is the answer a pre-event handler gives Twilio: let the action through, reject it, or let
+// it through with some of its properties rewritten.
+type Decision struct {
+	status int
+
+	body         *string
+	attributes   *string
+	friendlyName *string
+	uniqueName   *string
+}
+
+// Allow lets the action through unchanged.
+func Allow() Decision {
+	return Decision{status: http.StatusOK}
+}
+
+// Reject stops the action with the given 4xx or 5xx status. Any other status would let the action
+// through, so it is replaced with a 403.
+func Reject(status int) Decision {
+	if status < 400 || status > 599 {
+		status = http.StatusForbidden
+	}
+
+	return Decision{status: status}
+}
+
+// WithBody lets the action through with the Message body replaced.
+func (d Decision) WithBody(body string) Decision {
+	d.body = &body
+	return d
+}
+
+// WithAttributes lets the action through with the attributes of the Message, Channel or Member
+// replaced. The attributes must be a JSON object encoded as a string.
+func (d Decision) WithAttributes(attributes string) Decision {
+	d.attributes = &attributes
+	return d
+}
+
+// WithFriendlyName lets the action through with the Channel's friendly name replaced.
+func (d Decision) WithFriendlyName(friendlyName string) Decision {
+	d.friendlyName = &friendlyName
+	return d
+}
+
+// WithUniqueName lets the action through with the Channel's unique name replaced.
+func (d Decision) WithUniqueName(uniqueName string) Decision {
+	d.uniqueName = &uniqueName
+	return d
+}
+
+// Rejected reports whether the Decision stops the action.
+func (d Decision) Rejected() bool {
+	return d.status != 0 && (d.status < 200 || d.status > 299)
+}
+
+type modification struct {
+	Body         *string `json:"body,omitempty"`
+	Attributes   *string `json:"attributes,omitempty"`
+	FriendlyName *string `json:"friendlyName,omitempty"`
+	UniqueName   *string `json:"uniqueName,omitempty"`
+}
+
+// Write sends the Decision to Twilio in the format it expects from a pre-event webhook.
+func (d Decision) Write(w http.ResponseWriter) {
+	if d.Rejected() {
+		w.WriteHeader(d.status)
+		return
+	}
+
+	mod := modification{
+		Body:         d.body,
+		Attributes:   d.attributes,
+		FriendlyName: d.friendlyName,
+		UniqueName:   d.uniqueName,
+	}
+
+	if mod == (modification{}) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mod)
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReject(t *testing.T) {
+	tests := []struct {
+		status int
+		want   int
+	}{
+		{0, http.StatusForbidden},
+		{http.StatusContinue, http.StatusForbidden},
+		{http.StatusOK, http.StatusForbidden},
+		{http.StatusFound, http.StatusForbidden},
+		{http.StatusBadRequest, http.StatusBadRequest},
+		{http.StatusTooManyRequests, http.StatusTooManyRequests},
+		{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		{600, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		decision := Reject(test.status)
+		if !decision.Rejected() {
+			t.Errorf("Reject(%d).Rejected() = false", test.status)
+		}
+
+		w := httptest.NewRecorder()
+		decision.Write(w)
+		if w.Code != test.want {
+			t.Errorf("Reject(%d) wrote %d, want %d", test.status, w.Code, test.want)
+		}
+	}
+}
+
+func TestAllowWithModifications(t *testing.T) {
+	w := httptest.NewRecorder()
+	Allow().WithBody("edited").Write(w)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+
+	if got := strings.TrimSpace(w.Body.String()); got != `{"body":"edited"}` {
+		t.Errorf("body = %s, want the modified body", got)
+	}
+}
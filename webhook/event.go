@@ -0,0 +1,313 @@
+// Package webhook parses the events Twilio Chat posts to a Service's PreWebhookURL and
+// PostWebhookURL and routes them to typed handlers. Wrap a Mux with twiligo's
+// Client.RequireSignature to reject forged requests before they are parsed.
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownEvent is returned when a webhook carries an EventType this package doesn't model.
+var ErrUnknownEvent = errors.New("webhook: unknown event type")
+
+// EventType identifies the kind of activity a webhook describes.
+type EventType string
+
+// Post-event types, sent after an action has happened.
+const (
+	EventMessageSent      EventType = "onMessageSent"
+	EventMessageUpdated   EventType = "onMessageUpdated"
+	EventMessageRemoved   EventType = "onMessageRemoved"
+	EventChannelAdded     EventType = "onChannelAdded"
+	EventChannelUpdated   EventType = "onChannelUpdated"
+	EventChannelDestroyed EventType = "onChannelDestroyed"
+	EventMemberAdded      EventType = "onMemberAdded"
+	EventMemberUpdated    EventType = "onMemberUpdated"
+	EventMemberRemoved    EventType = "onMemberRemoved"
+	EventUserAdded        EventType = "onUserAdded"
+	EventUserUpdated      EventType = "onUserUpdated"
+)
+
+// Pre-event types, sent before an action happens so the receiver can intervene.
+const (
+	EventMessageSend    EventType = "onMessageSend"
+	EventMessageUpdate  EventType = "onMessageUpdate"
+	EventMessageRemove  EventType = "onMessageRemove"
+	EventChannelAdd     EventType = "onChannelAdd"
+	EventChannelUpdate  EventType = "onChannelUpdate"
+	EventChannelDestroy EventType = "onChannelDestroy"
+	EventMemberAdd      EventType = "onMemberAdd"
+	EventMemberUpdate   EventType = "onMemberUpdate"
+	EventMemberRemove   EventType = "onMemberRemove"
+	EventUserUpdate     EventType = "onUserUpdate"
+)
+
+// Event is implemented by every typed event this package parses.
+type Event interface {
+	Type() EventType
+}
+
+// Common holds the parameters Twilio sends with every webhook.
+type Common struct {
+	EventType      EventType
+	AccountSID     string
+	ServiceSID     string // Sent by Twilio as InstanceSid.
+	ClientIdentity string // The identity of the User that triggered the event, if any.
+	Source         string // Either "SDK" or "API".
+	RetryCount     int
+	WebhookType    string
+	WebhookSID     string
+}
+
+// Type returns the EventType of the webhook.
+func (c Common) Type() EventType {
+	return c.EventType
+}
+
+// MessageEvent holds the parameters Twilio sends with Message webhooks.
+type MessageEvent struct {
+	Common
+	ChannelSID  string
+	MessageSID  string // Empty for onMessageSend, the Message doesn't exist yet.
+	Index       int
+	Body        string
+	Attributes  string
+	From        string
+	ModifiedBy  string
+	DateCreated *time.Time
+	DateUpdated *time.Time
+}
+
+// ChannelEvent holds the parameters Twilio sends with Channel webhooks.
+type ChannelEvent struct {
+	Common
+	ChannelSID   string // Empty for onChannelAdd, the Channel doesn't exist yet.
+	ChannelType  string
+	UniqueName   string
+	FriendlyName string
+	Attributes   string
+	CreatedBy    string
+	DateCreated  *time.Time
+	DateUpdated  *time.Time
+}
+
+// MemberEvent holds the parameters Twilio sends with Member webhooks.
+type MemberEvent struct {
+	Common
+	ChannelSID  string
+	MemberSID   string // Empty for onMemberAdd, the Member doesn't exist yet.
+	Identity    string
+	RoleSID     string
+	Reason      string
+	DateCreated *time.Time
+	DateUpdated *time.Time
+}
+
+// UserEvent holds the parameters Twilio sends with User webhooks.
+type UserEvent struct {
+	Common
+	UserSID      string
+	Identity     string
+	FriendlyName string
+	RoleSID      string
+	Attributes   string
+	IsOnline     bool
+	IsNotifiable bool
+	DateCreated  *time.Time
+	DateUpdated  *time.Time
+}
+
+// Typed post-events.
+type (
+	MessageSentEvent      struct{ MessageEvent }
+	MessageUpdatedEvent   struct{ MessageEvent }
+	MessageRemovedEvent   struct{ MessageEvent }
+	ChannelAddedEvent     struct{ ChannelEvent }
+	ChannelUpdatedEvent   struct{ ChannelEvent }
+	ChannelDestroyedEvent struct{ ChannelEvent }
+	MemberAddedEvent      struct{ MemberEvent }
+	MemberUpdatedEvent    struct{ MemberEvent }
+	MemberRemovedEvent    struct{ MemberEvent }
+	UserAddedEvent        struct{ UserEvent }
+	UserUpdatedEvent      struct{ UserEvent }
+)
+
+// Typed pre-events.
+type (
+	MessageSendEvent    struct{ MessageEvent }
+	MessageUpdateEvent  struct{ MessageEvent }
+	MessageRemoveEvent  struct{ MessageEvent }
+	ChannelAddEvent     struct{ ChannelEvent }
+	ChannelUpdateEvent  struct{ ChannelEvent }
+	ChannelDestroyEvent struct{ ChannelEvent }
+	MemberAddEvent      struct{ MemberEvent }
+	MemberUpdateEvent   struct{ MemberEvent }
+	MemberRemoveEvent   struct{ MemberEvent }
+	UserUpdateEvent     struct{ UserEvent }
+)
+
+// Parse reads the webhook from the request and returns the typed event for its EventType, e.g. a
+// MessageSentEvent for onMessageSent. Parameters are read from the query string of GET requests,
+// which Twilio sends when the Service's WebhookMethod is GET, and from the form body otherwise.
+func Parse(r *http.Request) (Event, error) {
+	if r.Method == http.MethodGet {
+		return ParseForm(r.URL.Query())
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	return ParseForm(r.PostForm)
+}
+
+// ParseForm returns the typed event described by the webhook parameters.
+func ParseForm(form url.Values) (Event, error) {
+	common := parseCommon(form)
+
+	switch common.EventType {
+	case EventMessageSent:
+		return MessageSentEvent{parseMessage(common, form)}, nil
+	case EventMessageUpdated:
+		return MessageUpdatedEvent{parseMessage(common, form)}, nil
+	case EventMessageRemoved:
+		return MessageRemovedEvent{parseMessage(common, form)}, nil
+	case EventChannelAdded:
+		return ChannelAddedEvent{parseChannel(common, form)}, nil
+	case EventChannelUpdated:
+		return ChannelUpdatedEvent{parseChannel(common, form)}, nil
+	case EventChannelDestroyed:
+		return ChannelDestroyedEvent{parseChannel(common, form)}, nil
+	case EventMemberAdded:
+		return MemberAddedEvent{parseMember(common, form)}, nil
+	case EventMemberUpdated:
+		return MemberUpdatedEvent{parseMember(common, form)}, nil
+	case EventMemberRemoved:
+		return MemberRemovedEvent{parseMember(common, form)}, nil
+	case EventUserAdded:
+		return UserAddedEvent{parseUser(common, form)}, nil
+	case EventUserUpdated:
+		return UserUpdatedEvent{parseUser(common, form)}, nil
+	case EventMessageSend:
+		return MessageSendEvent{parseMessage(common, form)}, nil
+	case EventMessageUpdate:
+		return MessageUpdateEvent{parseMessage(common, form)}, nil
+	case EventMessageRemove:
+		return MessageRemoveEvent{parseMessage(common, form)}, nil
+	case EventChannelAdd:
+		return ChannelAddEvent{parseChannel(common, form)}, nil
+	case EventChannelUpdate:
+		return ChannelUpdateEvent{parseChannel(common, form)}, nil
+	case EventChannelDestroy:
+		return ChannelDestroyEvent{parseChannel(common, form)}, nil
+	case EventMemberAdd:
+		return MemberAddEvent{parseMember(common, form)}, nil
+	case EventMemberUpdate:
+		return MemberUpdateEvent{parseMember(common, form)}, nil
+	case EventMemberRemove:
+		return MemberRemoveEvent{parseMember(common, form)}, nil
+	case EventUserUpdate:
+		return UserUpdateEvent{parseUser(common, form)}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownEvent, common.EventType)
+}
+
+func parseCommon(form url.Values) Common {
+	return Common{
+		EventType:      EventType(form.Get("EventType")),
+		AccountSID:     form.Get("AccountSid"),
+		ServiceSID:     form.Get("InstanceSid"),
+		ClientIdentity: form.Get("ClientIdentity"),
+		Source:         form.Get("Source"),
+		RetryCount:     parseInt(form.Get("RetryCount")),
+		WebhookType:    form.Get("WebhookType"),
+		WebhookSID:     form.Get("WebhookSid"),
+	}
+}
+
+func parseMessage(common Common, form url.Values) MessageEvent {
+	return MessageEvent{
+		Common:      common,
+		ChannelSID:  form.Get("ChannelSid"),
+		MessageSID:  form.Get("MessageSid"),
+		Index:       parseInt(form.Get("Index")),
+		Body:        form.Get("Body"),
+		Attributes:  form.Get("Attributes"),
+		From:        form.Get("From"),
+		ModifiedBy:  form.Get("ModifiedBy"),
+		DateCreated: parseTime(form.Get("DateCreated")),
+		DateUpdated: parseTime(form.Get("DateUpdated")),
+	}
+}
+
+func parseChannel(common Common, form url.Values) ChannelEvent {
+	return ChannelEvent{
+		Common:       common,
+		ChannelSID:   form.Get("ChannelSid"),
+		ChannelType:  form.Get("ChannelType"),
+		UniqueName:   form.Get("UniqueName"),
+		FriendlyName: form.Get("FriendlyName"),
+		Attributes:   form.Get("Attributes"),
+		CreatedBy:    form.Get("CreatedBy"),
+		DateCreated:  parseTime(form.Get("DateCreated")),
+		DateUpdated:  parseTime(form.Get("DateUpdated")),
+	}
+}
+
+func parseMember(common Common, form url.Values) MemberEvent {
+	return MemberEvent{
+		Common:      common,
+		ChannelSID:  form.Get("ChannelSid"),
+		MemberSID:   form.Get("MemberSid"),
+		Identity:    form.Get("Identity"),
+		RoleSID:     form.Get("RoleSid"),
+		Reason:      form.Get("Reason"),
+		DateCreated: parseTime(form.Get("DateCreated")),
+		DateUpdated: parseTime(form.Get("DateUpdated")),
+	}
+}
+
+func parseUser(common Common, form url.Values) UserEvent {
+	return UserEvent{
+		Common:       common,
+		UserSID:      form.Get("UserSid"),
+		Identity:     form.Get("Identity"),
+		FriendlyName: form.Get("FriendlyName"),
+		RoleSID:      form.Get("RoleSid"),
+		Attributes:   form.Get("Attributes"),
+		IsOnline:     parseBool(form.Get("IsOnline")),
+		IsNotifiable: parseBool(form.Get("IsNotifiable")),
+		DateCreated:  parseTime(form.Get("DateCreated")),
+		DateUpdated:  parseTime(form.Get("DateUpdated")),
+	}
+}
+
+func parseInt(value string) int {
+	n, _ := strconv.Atoi(value)
+	return n
+}
+
+func parseBool(value string) bool {
+	b, _ := strconv.ParseBool(value)
+	return b
+}
+
+// parseTime returns nil for missing or malformed timestamps rather than failing the whole event.
+func parseTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
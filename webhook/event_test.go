@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func messageSentForm() url.Values {
+	return url.Values{
+		"EventType":   {"onMessageSent"},
+		"InstanceSid": {"IS123"},
+		"ChannelSid":  {"CH123"},
+		"MessageSid":  {"IM123"},
+		"Index":       {"4"},
+		"Body":        {"hello there"},
+		"From":        {"alice"},
+		"DateCreated": {"2020-01-02T03:04:05Z"},
+	}
+}
+
+// newWebhookRequest builds the request Twilio sends for the given WebhookMethod.
+func newWebhookRequest(method string, form url.Values) *http.Request {
+	if method == http.MethodGet {
+		return httptest.NewRequest(method, "https://example.com/hook?"+form.Encode(), nil)
+	}
+
+	r := httptest.NewRequest(method, "https://example.com/hook", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+func TestParse(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		t.Run(method, func(t *testing.T) {
+			event, err := Parse(newWebhookRequest(method, messageSentForm()))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			sent, ok := event.(MessageSentEvent)
+			if !ok {
+				t.Fatalf("Parse returned %T, want MessageSentEvent", event)
+			}
+
+			if sent.ServiceSID != "IS123" || sent.ChannelSID != "CH123" || sent.MessageSID != "IM123" {
+				t.Errorf("wrong SIDs: %+v", sent)
+			}
+
+			if sent.Index != 4 || sent.Body != "hello there" || sent.From != "alice" {
+				t.Errorf("wrong message: %+v", sent)
+			}
+
+			if sent.DateCreated == nil || sent.DateCreated.Year() != 2020 {
+				t.Errorf("DateCreated = %v, want 2020-01-02", sent.DateCreated)
+			}
+		})
+	}
+}
+
+func TestParseUnknownEvent(t *testing.T) {
+	form := url.Values{"EventType": {"onSomethingNew"}}
+
+	if _, err := ParseForm(form); !errors.Is(err, ErrUnknownEvent) {
+		t.Fatalf("ParseForm error = %v, want ErrUnknownEvent", err)
+	}
+}
+
+func TestMuxDispatchesBothMethods(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		t.Run(method, func(t *testing.T) {
+			var got MessageSentEvent
+			mux := NewMux()
+			mux.OnMessageSent(func(_ context.Context, event MessageSentEvent) {
+				got = event
+			})
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, newWebhookRequest(method, messageSentForm()))
+
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want 200", w.Code)
+			}
+
+			if got.MessageSID != "IM123" {
+				t.Errorf("handler got %+v, want MessageSid IM123", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Mux is an http.Handler that parses incoming webhooks and dispatches them to the handler
+// registered for their EventType. Events without a handler are acknowledged and ignored, which
+// allows pre-events through unchanged.
+type Mux struct {
+	mu       sync.RWMutex
+	handlers map[EventType]func(context.Context, Event) Decision
+
+	// ErrorHandler, if set, is called when a webhook can't be parsed. By default a 400 is returned.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// NewMux creates a new Mux with no handlers registered.
+func NewMux() *Mux {
+	return &Mux{
+		handlers: make(map[EventType]func(context.Context, Event) Decision),
+	}
+}
+
+// handle registers fn for the given post-event type, replacing any previous handler.
+func handle[E Event](m *Mux, eventType EventType, fn func(context.Context, E)) {
+	decide(m, eventType, func(ctx context.Context, event E) Decision {
+		fn(ctx, event)
+		return Allow()
+	})
+}
+
+// decide registers fn for the given pre-event type, replacing any previous handler.
+func decide[E Event](m *Mux, eventType EventType, fn func(context.Context, E) Decision) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.handlers[eventType] = func(ctx context.Context, event Event) Decision {
+		return fn(ctx, event.(E))
+	}
+}
+
+// OnMessageSent registers the handler for onMessageSent events.
+func (m *Mux) OnMessageSent(fn func(context.Context, MessageSentEvent)) {
+	handle(m, EventMessageSent, fn)
+}
+
+// OnMessageUpdated registers the handler for onMessageUpdated events.
+func (m *Mux) OnMessageUpdated(fn func(context.Context, MessageUpdatedEvent)) {
+	handle(m, EventMessageUpdated, fn)
+}
+
+// OnMessageRemoved registers the handler for onMessageRemoved events.
+func (m *Mux) OnMessageRemoved(fn func(context.Context, MessageRemovedEvent)) {
+	handle(m, EventMessageRemoved, fn)
+}
+
+// OnChannelAdded registers the handler for onChannelAdded events.
+func (m *Mux) OnChannelAdded(fn func(context.Context, ChannelAddedEvent)) {
+	handle(m, EventChannelAdded, fn)
+}
+
+// OnChannelUpdated registers the handler for onChannelUpdated events.
+func (m *Mux) OnChannelUpdated(fn func(context.Context, ChannelUpdatedEvent)) {
+	handle(m, EventChannelUpdated, fn)
+}
+
+// OnChannelDestroyed registers the handler for onChannelDestroyed events.
+func (m *Mux) OnChannelDestroyed(fn func(context.Context, ChannelDestroyedEvent)) {
+	handle(m, EventChannelDestroyed, fn)
+}
+
+// OnMemberAdded registers the handler for onMemberAdded events.
+func (m *Mux) OnMemberAdded(fn func(context.Context, MemberAddedEvent)) {
+	handle(m, EventMemberAdded, fn)
+}
+
+// OnMemberUpdated registers the handler for onMemberUpdated events.
+func (m *Mux) OnMemberUpdated(fn func(context.Context, MemberUpdatedEvent)) {
+	handle(m, EventMemberUpdated, fn)
+}
+
+// OnMemberRemoved registers the handler for onMemberRemoved events.
+func (m *Mux) OnMemberRemoved(fn func(context.Context, MemberRemovedEvent)) {
+	handle(m, EventMemberRemoved, fn)
+}
+
+// OnUserAdded registers the handler for onUserAdded events.
+func (m *Mux) OnUserAdded(fn func(context.Context, UserAddedEvent)) {
+	handle(m, EventUserAdded, fn)
+}
+
+// OnUserUpdated registers the handler for onUserUpdated events.
+func (m *Mux) OnUserUpdated(fn func(context.Context, UserUpdatedEvent)) {
+	handle(m, EventUserUpdated, fn)
+}
+
+// OnMessageSend registers the handler deciding whether a Message may be sent.
+func (m *Mux) OnMessageSend(fn func(context.Context, MessageSendEvent) Decision) {
+	decide(m, EventMessageSend, fn)
+}
+
+// OnMessageUpdate registers the handler deciding whether a Message may be updated.
+func (m *Mux) OnMessageUpdate(fn func(context.Context, MessageUpdateEvent) Decision) {
+	decide(m, EventMessageUpdate, fn)
+}
+
+// OnMessageRemove registers the handler deciding whether a Message may be removed.
+func (m *Mux) OnMessageRemove(fn func(context.Context, MessageRemoveEvent) Decision) {
+	decide(m, EventMessageRemove, fn)
+}
+
+// OnChannelAdd registers the handler deciding whether a Channel may be created.
+func (m *Mux) OnChannelAdd(fn func(context.Context, ChannelAddEvent) Decision) {
+	decide(m, EventChannelAdd, fn)
+}
+
+// OnChannelUpdate registers the handler deciding whether a Channel may be updated.
+func (m *Mux) OnChannelUpdate(fn func(context.Context, ChannelUpdateEvent) Decision) {
+	decide(m, EventChannelUpdate, fn)
+}
+
+// OnChannelDestroy registers the handler deciding whether a Channel may be destroyed.
+func (m *Mux) OnChannelDestroy(fn func(context.Context, ChannelDestroyEvent) Decision) {
+	decide(m, EventChannelDestroy, fn)
+}
+
+// OnMemberAdd registers the handler deciding whether a Member may join a Channel.
+func (m *Mux) OnMemberAdd(fn func(context.Context, MemberAddEvent) Decision) {
+	decide(m, EventMemberAdd, fn)
+}
+
+// OnMemberUpdate registers the handler deciding whether a Member may be updated.
+func (m *Mux) OnMemberUpdate(fn func(context.Context, MemberUpdateEvent) Decision) {
+	decide(m, EventMemberUpdate, fn)
+}
+
+// OnMemberRemove registers the handler deciding whether a Member may leave a Channel.
+func (m *Mux) OnMemberRemove(fn func(context.Context, MemberRemoveEvent) Decision) {
+	decide(m, EventMemberRemove, fn)
+}
+
+// OnUserUpdate registers the handler deciding whether a User may be updated.
+func (m *Mux) OnUserUpdate(fn func(context.Context, UserUpdateEvent) Decision) {
+	decide(m, EventUserUpdate, fn)
+}
+
+// ServeHTTP parses the webhook and calls the handler registered for its EventType, writing the
+// handler's Decision back to Twilio.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event, err := Parse(r)
+
+	if errors.Is(err, ErrUnknownEvent) {
+		// Twilio may add events before we model them, there's no reason to make it retry.
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err != nil {
+		if m.ErrorHandler != nil {
+			m.ErrorHandler(w, r, err)
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.RLock()
+	handler, ok := m.handlers[event.Type()]
+	m.mu.RUnlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	handler(r.Context(), event).Write(w)
+}